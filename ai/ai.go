@@ -0,0 +1,83 @@
+//Package ai implements computer players for the games of package games.
+//They search a game by applying plies to its GameLogic and undoing them
+//again, so the logic is back in its original state once a search returns.
+package ai
+
+import (
+	"errors"
+
+	"github.com/er4z0r/tictacgo/games"
+)
+
+//Errors returned if there is no ply to choose from
+var (
+	ErrGameOver = errors.New("the game is already over")
+	ErrNoMoves  = errors.New("there are no legal moves")
+)
+
+//Agent chooses the plies of a computer player
+type Agent interface {
+	//BestMove returns the ply the player whose turn it is should conduct next
+	BestMove(g games.GameLogic) (games.Ply, error)
+}
+
+//Evaluator rates the state of an unfinished game from the point of view
+//of player p. Higher values are better for p.
+type Evaluator func(g games.GameLogic, p *games.Player) int
+
+//scorer is implemented by game logics that decide the winner by score
+type scorer interface {
+	Score(p *games.Player) int
+}
+
+//ScoreEvaluator rates a game by the difference between the score of
+//player p and the best score of the other players.
+//Games without a score are rated 0.
+func ScoreEvaluator(g games.GameLogic, p *games.Player) int {
+	sc, ok := g.(scorer)
+	if !ok {
+		return 0
+	}
+	best, found := 0, false
+	for _, o := range g.Players() {
+		if *o == *p {
+			continue
+		}
+		if s := sc.Score(o); !found || s > best {
+			best, found = s, true
+		}
+	}
+	return sc.Score(p) - best
+}
+
+//legalMoves returns the legal plies of the player whose turn it is or an
+//error if there are none
+func legalMoves(g games.GameLogic) ([]games.Ply, error) {
+	p := g.WhoseTurn()
+	if p == nil || g.IsOver() {
+		return nil, ErrGameOver
+	}
+	moves := g.LegalMoves(p)
+	if len(moves) == 0 {
+		return nil, ErrNoMoves
+	}
+	return moves, nil
+}
+
+//rebuild returns a game returned by newGame in the start position of g
+//with the first n plies of g applied, e.g. to search g concurrently
+func rebuild(newGame func() (games.GameLogic, error), g games.GameLogic, n int) (games.GameLogic, error) {
+	c, err := newGame()
+	if err != nil {
+		return nil, err
+	}
+	if start := games.StartPosition(g); start != nil {
+		if err := games.SetUp(c, start); err != nil {
+			return nil, err
+		}
+	}
+	if err := games.Replay(c, g.History()[:n]); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,220 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/er4z0r/tictacgo/games"
+)
+
+//Hint is the ply an Agent suggests to the player whose turn it is
+//together with the reason for it
+type Hint struct {
+	Ply games.Ply
+	//Wins is true, if Ply wins the game right away
+	Wins bool
+	//Threat is the opponent who would win with the next ply at the target
+	//of Ply, if Ply did not block it, otherwise nil
+	Threat *games.Player
+	//Line names the line the Threat would win on, e.g. column. It is
+	//empty if the line is not known.
+	Line string
+	//Value is the minimax value of Ply, if the hint was given by a Minimax
+	Value int
+	rated bool
+}
+
+func (h Hint) String() string {
+	at := formatCoords(h.Ply.Coords)
+	if h.Ply.Action == games.Move && len(h.Ply.Coords) == 4 {
+		at = fmt.Sprintf("from %s to %s", formatCoords(h.Ply.Coords[:2]), formatCoords(h.Ply.Coords[2:]))
+	}
+	switch {
+	case h.Wins:
+		return fmt.Sprintf("%v %s at %s, it wins", h.Ply.Action, h.Ply.Player.Symbol, at)
+	case h.Threat != nil && h.Line != "":
+		return fmt.Sprintf("block %s at %s, otherwise %s wins on the %s", h.Threat.Symbol, at, h.Threat.Symbol, h.Line)
+	case h.Threat != nil:
+		return fmt.Sprintf("block %s at %s, otherwise %s wins", h.Threat.Symbol, at, h.Threat.Symbol)
+	case h.rated && outcome(h.Value) == Win:
+		return fmt.Sprintf("%v %s at %s, it wins in %d plies", h.Ply.Action, h.Ply.Player.Symbol, at, WinScore-h.Value)
+	case h.rated && outcome(h.Value) == Loss:
+		return fmt.Sprintf("%v %s at %s, it delays the loss for %d plies", h.Ply.Action, h.Ply.Player.Symbol, at, WinScore+h.Value)
+	}
+	return fmt.Sprintf("%v %s at %s", h.Ply.Action, h.Ply.Player.Symbol, at)
+}
+
+//Suggest returns the ply agent a chooses for the player whose turn it is
+//and explains whether it wins right away or blocks a win of the opponent.
+//The plies waiting to be redone are kept.
+func Suggest(g games.GameLogic, a Agent) (Hint, error) {
+	defer games.KeepRedo(g)()
+	moves, err := legalMoves(g)
+	if err != nil {
+		return Hint{}, err
+	}
+	m, err := a.BestMove(g)
+	if err != nil {
+		return Hint{}, err
+	}
+	h := Hint{Ply: m}
+	me := g.WhoseTurn()
+
+	if err := g.Apply(m); err != nil {
+		return Hint{}, err
+	}
+	if w := g.GetWinner(); g.IsOver() && w != nil && *w == *me {
+		h.Wins = true
+	}
+	if mm, ok := a.(*Minimax); ok {
+		h.Value, h.rated = mm.search(g, me, 1, -WinScore-1, WinScore+1), true
+	}
+	g.Undo()
+	if h.Wins {
+		return h, nil
+	}
+
+	//if another ply was conducted instead, could the opponent win at the
+	//target of m?
+	for _, other := range moves {
+		if samePly(other, m) {
+			continue
+		}
+		if err := g.Apply(other); err != nil {
+			continue
+		}
+		if opp := g.WhoseTurn(); !g.IsOver() && opp != nil && g.Apply(games.NewPly(m.Action, opp, m.Coords...)) == nil {
+			if w := g.GetWinner(); w != nil && *w == *opp {
+				h.Threat = w
+				h.Line = line(g, w, target(m))
+			}
+			g.Undo()
+		}
+		g.Undo()
+		if h.Threat != nil {
+			break
+		}
+	}
+	return h, nil
+}
+
+//Mistake is a ply that turned a won or drawn game into a lost one
+type Mistake struct {
+	//Index is the position of Ply in the history of the game
+	Index int
+	Ply   games.Ply
+	//Best is the ply that would have kept the result Before
+	Best   games.Ply
+	Before Result
+	After  Result
+}
+
+func (m Mistake) String() string {
+	return fmt.Sprintf("%d. %v turned a %v into a %v, %s was better", m.Index+1, m.Ply, m.Before, m.After, formatCoords(m.Best.Coords))
+}
+
+//Analyze rates every ply in the history of the finished game g using m and
+//returns those that turned a won or drawn game into a lost one for the
+//player conducting it. Only mistakes of players that lost according to
+//GetWinner are returned, the others were not exploited by their opponent.
+//The plies are replayed on a game returned by newGame, which has to be a
+//new game of the same kind with the same players, so g is not changed.
+func Analyze(g games.GameLogic, newGame func() (games.GameLogic, error), m *Minimax) ([]Mistake, error) {
+	if !g.IsOver() {
+		return nil, fmt.Errorf("the game is not over yet")
+	}
+	winner := g.GetWinner()
+	replayed, err := rebuild(newGame, g, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var mistakes []Mistake
+	for i, p := range g.History() {
+		if winner != nil && *winner != *p.Player {
+			rated, err := m.Rate(replayed)
+			if err != nil {
+				return nil, err
+			}
+			var best RatedMove
+			played := 0
+			for j, rm := range rated {
+				if j == 0 || rm.Value > best.Value {
+					best = rm
+				}
+				if samePly(rm.Ply, p) {
+					played = rm.Value
+				}
+			}
+			if before, after := outcome(best.Value), outcome(played); before != Loss && after == Loss {
+				mistakes = append(mistakes, Mistake{Index: i, Ply: p, Best: best.Ply, Before: before, After: after})
+			}
+		}
+		if err := replayed.Apply(p); err != nil {
+			return nil, err
+		}
+	}
+	return mistakes, nil
+}
+
+//outcome returns the Result a minimax value stands for
+func outcome(v int) Result {
+	switch {
+	case v > WinScore/2:
+		return Win
+	case v < -WinScore/2:
+		return Loss
+	}
+	return Draw
+}
+
+//samePly returns true, if a and b conduct the same action at the same
+//coordinates
+func samePly(a, b games.Ply) bool {
+	if a.Action != b.Action || len(a.Coords) != len(b.Coords) {
+		return false
+	}
+	for i := range a.Coords {
+		if a.Coords[i] != b.Coords[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//line names the row, column or diagonal through field t of the 2D board
+//of game g holding most of the pieces of player p
+func line(g games.GameLogic, p *games.Player, t []int) string {
+	b, ok := games.BoardOf(g)
+	if !ok || len(t) != 2 {
+		return ""
+	}
+	owned := func(x, y int) bool {
+		return games.CheckBounds(b, x, y) == nil && b.Get(x, y) == p.Symbol
+	}
+	names := []string{"row", "column", "diagonal", "diagonal"}
+	best, longest := "", 1
+	for i, d := range [][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}} {
+		n := 1
+		for _, sign := range []int{1, -1} {
+			x, y := t[0]+sign*d[0], t[1]+sign*d[1]
+			for owned(x, y) {
+				n++
+				x, y = x+sign*d[0], y+sign*d[1]
+			}
+		}
+		if n > longest {
+			best, longest = names[i], n
+		}
+	}
+	return best
+}
+
+//formatCoords joins coordinates for messages, e.g. 1,2
+func formatCoords(coords []int) string {
+	s := make([]string, len(coords))
+	for i, c := range coords {
+		s[i] = fmt.Sprint(c)
+	}
+	return strings.Join(s, ",")
+}
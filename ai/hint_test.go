@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"testing"
+
+	. "github.com/er4z0r/tictacgo/games"
+)
+
+func TestSuggest(t *testing.T) {
+	//o|o|
+	//x|x|
+	// | |
+	_, g, _, _ := ticTacToe(t, []int{0, 0, 1, 0}, []int{0, 1, 1, 1})
+	h, err := Suggest(g, NewMinimax(0))
+	if err != nil || !h.Wins || h.String() != "place o at 2,0, it wins" {
+		t.Errorf("Suggest failed. Returned %q (%v)", h, err)
+	}
+
+	//o|x|
+	// |x|
+	// | |o
+	_, g, _, p2 := ticTacToe(t, nil, nil)
+	for _, c := range [][]int{{0, 0}, {1, 0}, {2, 2}, {1, 1}} {
+		if err := g.Apply(NewPly(Place, g.WhoseTurn(), c...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h, err = Suggest(g, NewMinimax(0))
+	if err != nil || h.Threat != p2 || h.Line != "column" {
+		t.Errorf("Suggest failed. Returned %q (%v)", h, err)
+	}
+	if e := "block x at 1,2, otherwise x wins on the column"; h.String() != e {
+		t.Errorf("Suggest failed. Explained %q expected %q", h, e)
+	}
+	if len(g.History()) != 4 {
+		t.Errorf("Suggest failed. Changed the history to %v", g.History())
+	}
+	if err := g.Redo(); err == nil || err.Error() != "there is nothing to redo" {
+		t.Errorf("Suggest failed. Left %v to redo", g.History()[4:])
+	}
+
+	//the plies undone by the player are kept
+	g.Undo()
+	if _, err := Suggest(g, NewMinimax(0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Redo(); err != nil || len(g.History()) != 4 {
+		t.Errorf("Suggest failed. Redo returned %v with the history %v", err, g.History())
+	}
+
+	//the line is read from the board, which holds pieces that are not in
+	//the history
+	_, g, _, p2 = ticTacToe(t, []int{0, 0, 2, 2}, []int{1, 0, 1, 1})
+	h, err = Suggest(g, NewMinimax(0))
+	if err != nil || h.Threat != p2 || h.Line != "column" {
+		t.Errorf("Suggest failed. Returned %q on a set up board (%v)", h, err)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	_, g, p1, p2 := ticTacToe(t, nil, nil)
+	//o opens in a corner and x answers on an edge, which loses
+	for _, c := range [][]int{{0, 0}, {1, 0}, {1, 1}, {2, 2}, {0, 1}, {2, 1}, {0, 2}} {
+		if err := g.Apply(NewPly(Place, g.WhoseTurn(), c...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w := g.GetWinner(); w != p1 {
+		t.Fatalf("The game was won by %v", w)
+	}
+	history := g.History()
+
+	mistakes, err := Analyze(g, newTicTacToe, NewMinimax(0))
+	if err != nil || len(mistakes) != 1 {
+		t.Fatalf("Analyze failed. Returned %v (%v)", mistakes, err)
+	}
+	if m := mistakes[0]; m.Index != 1 || m.Ply.Player != p2 || m.Before != Draw || m.After != Loss {
+		t.Errorf("Analyze failed. Returned %v", m)
+	}
+	if len(g.History()) != len(history) || g.GetWinner() != p1 {
+		t.Errorf("Analyze failed. Changed the history to %v", g.History())
+	}
+
+	_, g, _, _ = ticTacToe(t, nil, nil)
+	if _, err := Analyze(g, newTicTacToe, NewMinimax(0)); err == nil {
+		t.Errorf("Analyze failed. Analyzed an unfinished game")
+	}
+}
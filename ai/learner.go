@@ -0,0 +1,343 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/er4z0r/tictacgo/games"
+)
+
+//ErrNotHashable is returned by a Learner for games not implementing
+//games.Hasher, as it identifies positions by their hash
+var ErrNotHashable = errors.New("the game does not implement games.Hasher")
+
+//Default parameters of a Learner returned by NewLearner
+const (
+	DefaultAlpha   = 0.2
+	DefaultEpsilon = 0.1
+)
+
+//unknownValue is the value of positions a Learner has not seen yet
+const unknownValue = 0.5
+
+//Learner is a computer player learning by self-play with temporal
+//difference learning, TD(0). It estimates the probability to win for the
+//player who conducted the last ply of every position it has seen, where
+//draws count half, and chooses the ply leading to the best position.
+type Learner struct {
+	//Alpha is the learning rate, the fraction of the difference between
+	//the values of successive positions a position is changed by
+	Alpha float64
+	//Epsilon is the probability of a random ply during training
+	Epsilon float64
+	//Seed initializes the random choices. The current time is used if 0.
+	Seed int64
+	//Game names the game the values were learned on, e.g. the m,n,k of
+	//an m,n,k-game. Positions are only identified by the pieces on the
+	//board, so values of different games must not be mixed.
+	Game   string
+	values map[uint64]float64
+	rnd    *rand.Rand
+}
+
+//NewLearner returns a Learner that has not learned anything yet
+func NewLearner() *Learner {
+	return &Learner{Alpha: DefaultAlpha, Epsilon: DefaultEpsilon, values: make(map[uint64]float64)}
+}
+
+//random returns the source of the random choices of l
+func (l *Learner) random() *rand.Rand {
+	if l.rnd == nil {
+		seed := l.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		l.rnd = rand.New(rand.NewSource(seed))
+	}
+	return l.rnd
+}
+
+//Len returns the number of positions l has learned a value for
+func (l *Learner) Len() int {
+	return len(l.values)
+}
+
+//value returns the estimated value of the position of g for player me,
+//who conducted the last ply. Finished games are rated by their winner.
+func (l *Learner) value(g games.GameLogic, hash uint64, me *games.Player) float64 {
+	if g.IsOver() {
+		return reward(g.GetWinner(), me)
+	}
+	if v, ok := l.values[hash]; ok {
+		return v
+	}
+	return unknownValue
+}
+
+//reward returns the value of a game won by w for player me
+func reward(w, me *games.Player) float64 {
+	switch {
+	case w == nil:
+		return 0.5
+	case *w == *me:
+		return 1
+	}
+	return 0
+}
+
+//greedy returns the ply of moves leading to the position with the highest
+//value, breaking ties randomly, and the hash of that position
+func (l *Learner) greedy(g games.GameLogic, h games.Hasher, moves []games.Ply) (games.Ply, uint64, error) {
+	me := g.WhoseTurn()
+	var best []games.Ply
+	var hashes []uint64
+	bestValue := -1.0
+	for _, m := range moves {
+		if err := g.Apply(m); err != nil {
+			return games.Ply{}, 0, err
+		}
+		hash := h.Hash()
+		v := l.value(g, hash, me)
+		g.Undo()
+		if v > bestValue {
+			best, hashes, bestValue = nil, nil, v
+		}
+		if v == bestValue {
+			best, hashes = append(best, m), append(hashes, hash)
+		}
+	}
+	i := l.random().Intn(len(best))
+	return best[i], hashes[i], nil
+}
+
+//BestMove implements the Agent interface. It never explores.
+func (l *Learner) BestMove(g games.GameLogic) (games.Ply, error) {
+	h, ok := g.(games.Hasher)
+	if !ok {
+		return games.Ply{}, ErrNotHashable
+	}
+	moves, err := legalMoves(g)
+	if err != nil {
+		return games.Ply{}, err
+	}
+	m, _, err := l.greedy(g, h, moves)
+	return m, err
+}
+
+//Train lets l play n games against itself. newGame has to return a new
+//game in its initial state. After every ply the value of the position
+//after the previous ply of the same player is moved towards the value of
+//the new position, unless the new ply was a random one.
+func (l *Learner) Train(newGame func() (games.GameLogic, error), n int) error {
+	for i := 0; i < n; i++ {
+		if err := l.episode(newGame); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//episode plays and learns from a single game of self-play
+func (l *Learner) episode(newGame func() (games.GameLogic, error)) error {
+	g, err := newGame()
+	if err != nil {
+		return err
+	}
+	h, ok := g.(games.Hasher)
+	if !ok {
+		return ErrNotHashable
+	}
+	r := l.random()
+	//last holds the position after the previous ply of each player
+	last := make(map[games.Player]uint64)
+	for !g.IsOver() {
+		p := g.WhoseTurn()
+		moves := g.LegalMoves(p)
+		if len(moves) == 0 {
+			break
+		}
+		if r.Float64() < l.Epsilon {
+			if err := g.Apply(moves[r.Intn(len(moves))]); err != nil {
+				return err
+			}
+			last[*p] = h.Hash()
+			continue
+		}
+		m, hash, err := l.greedy(g, h, moves)
+		if err != nil {
+			return err
+		}
+		if err := g.Apply(m); err != nil {
+			return err
+		}
+		if prev, ok := last[*p]; ok {
+			l.learn(prev, l.value(g, hash, p))
+		}
+		last[*p] = hash
+	}
+
+	w := g.GetWinner()
+	for _, p := range g.Players() {
+		if prev, ok := last[*p]; ok {
+			l.learn(prev, reward(w, p))
+		}
+	}
+	return nil
+}
+
+//learn moves the value of the position with the given hash towards target
+func (l *Learner) learn(hash uint64, target float64) {
+	v, ok := l.values[hash]
+	if !ok {
+		v = unknownValue
+	}
+	l.values[hash] = v + l.Alpha*(target-v)
+}
+
+//learnerMagic identifies files written by Learner.Save
+const learnerMagic = "TTGQ"
+
+//maxLearnerGame is the maximum length of the game stored by Learner.Save
+const maxLearnerGame = 1 << 10
+
+//learnerVersion is the version of the file format written by Learner.Save.
+//Version 1 did not store the game.
+const learnerVersion = 2
+
+//Save writes the game and the learned values of l to w. Positions are
+//sorted by hash and stored as the difference to the previous hash followed
+//by the value.
+func (l *Learner) Save(w io.Writer) error {
+	hashes := make([]uint64, 0, len(l.values))
+	for h := range l.values {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(learnerMagic)
+	bw.WriteByte(learnerVersion)
+	buf := make([]byte, binary.MaxVarintLen64)
+	bw.Write(buf[:binary.PutUvarint(buf, uint64(len(l.Game)))])
+	bw.WriteString(l.Game)
+	bw.Write(buf[:binary.PutUvarint(buf, uint64(len(hashes)))])
+	var last uint64
+	for _, h := range hashes {
+		bw.Write(buf[:binary.PutUvarint(buf, h-last)])
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(l.values[h]))
+		bw.Write(buf[:8])
+		last = h
+	}
+	return bw.Flush()
+}
+
+//LoadLearner reads the values written by Learner.Save from r and returns
+//a Learner using them with the default parameters. The game of files of
+//version 1 is empty.
+func LoadLearner(r io.Reader) (*Learner, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(learnerMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, err
+	}
+	if string(header[:len(learnerMagic)]) != learnerMagic {
+		return nil, fmt.Errorf("not a learner table")
+	}
+	l := NewLearner()
+	switch v := header[len(learnerMagic)]; v {
+	case 1:
+	case learnerVersion:
+		n, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		if n > maxLearnerGame {
+			return nil, fmt.Errorf("the name of the game is %d bytes long", n)
+		}
+		game := make([]byte, n)
+		if _, err := io.ReadFull(br, game); err != nil {
+			return nil, err
+		}
+		l.Game = string(game)
+	default:
+		return nil, fmt.Errorf("unsupported learner table version %d", v)
+	}
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 8)
+	var last uint64
+	for i := uint64(0); i < n; i++ {
+		d, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, err
+		}
+		last += d
+		l.values[last] = math.Float64frombits(binary.LittleEndian.Uint64(buf))
+	}
+	return l, nil
+}
+
+//Record counts the results of several games from the point of view of
+//one player
+type Record struct {
+	Wins, Draws, Losses int
+}
+
+func (r Record) String() string {
+	return fmt.Sprintf("%d wins, %d draws, %d losses", r.Wins, r.Draws, r.Losses)
+}
+
+//Play lets agent a play n games against agent b and returns the results
+//of a. newGame has to return a new two player game in its initial state.
+//The agents take turns in starting the games, a starts the first one.
+func Play(newGame func() (games.GameLogic, error), a, b Agent, n int) (Record, error) {
+	var rec Record
+	for i := 0; i < n; i++ {
+		g, err := newGame()
+		if err != nil {
+			return rec, err
+		}
+		players := g.Players()
+		if len(players) != 2 {
+			return rec, fmt.Errorf("the game has %d players instead of 2", len(players))
+		}
+		me := players[i%2]
+		for !g.IsOver() {
+			agent := b
+			if *g.WhoseTurn() == *me {
+				agent = a
+			}
+			m, err := agent.BestMove(g)
+			if err == ErrNoMoves {
+				break
+			}
+			if err != nil {
+				return rec, err
+			}
+			if err := g.Apply(m); err != nil {
+				return rec, err
+			}
+		}
+		switch w := g.GetWinner(); {
+		case w == nil:
+			rec.Draws++
+		case *w == *me:
+			rec.Wins++
+		default:
+			rec.Losses++
+		}
+	}
+	return rec, nil
+}
@@ -0,0 +1,74 @@
+package ai
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/er4z0r/tictacgo/games"
+)
+
+func newTicTacToe() (GameLogic, error) {
+	b, err := NewSimple2DBoard(3, 3)
+	if err != nil {
+		return nil, err
+	}
+	return NewBaseLogic(b, &Player{Name: "Alice", Symbol: "o"}, &Player{Name: "Bob", Symbol: "x"})
+}
+
+func TestLearnerTrain(t *testing.T) {
+	l := NewLearner()
+	l.Seed = 1
+	if err := l.Train(newTicTacToe, 5000); err != nil {
+		t.Fatalf("Train failed. Returned %v", err)
+	}
+	if l.Len() == 0 || l.Len() > 5478 {
+		t.Errorf("Train failed. Learned %d positions", l.Len())
+	}
+
+	random := &Opponent{Agent: NewMinimax(1), BlunderRate: 1, Seed: 2}
+	rec, err := Play(newTicTacToe, l, random, 200)
+	t.Logf("against random plies: %v", rec)
+	if err != nil || rec.Wins+rec.Draws+rec.Losses != 200 || rec.Losses > 10 || rec.Wins < 100 {
+		t.Errorf("Train failed. The learner achieved %v (%v) against random plies", rec, err)
+	}
+	rec, err = Play(newTicTacToe, l, NewMinimax(0), 20)
+	t.Logf("against minimax: %v", rec)
+	if err != nil || rec.Wins != 0 {
+		t.Errorf("Play failed. The learner achieved %v (%v) against perfect play", rec, err)
+	}
+}
+
+func TestLearnerSaveLoad(t *testing.T) {
+	l := NewLearner()
+	l.Seed = 1
+	l.Game = "3,3,3"
+	if err := l.Train(newTicTacToe, 500); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := l.Save(&buf); err != nil {
+		t.Fatalf("Save failed. Returned %v", err)
+	}
+	loaded, err := LoadLearner(bytes.NewReader(buf.Bytes()))
+	if err != nil || loaded.Len() != l.Len() || loaded.Game != l.Game {
+		t.Fatalf("LoadLearner failed. Returned %v", err)
+	}
+	for h, v := range l.values {
+		if loaded.values[h] != v {
+			t.Errorf("LoadLearner failed. Got %v for %x expected %v", loaded.values[h], h, v)
+		}
+	}
+
+	if _, err := LoadLearner(bytes.NewReader(buf.Bytes()[:buf.Len()-1])); err == nil {
+		t.Errorf("LoadLearner failed. Accepted a truncated table")
+	}
+	if _, err := LoadLearner(bytes.NewReader([]byte("TTGS\x01"))); err == nil {
+		t.Errorf("LoadLearner failed. Accepted a solver table")
+	}
+
+	//files of version 1 have no game
+	v1 := append([]byte("TTGQ\x01"), buf.Bytes()[len("TTGQ\x02\x053,3,3"):]...)
+	if loaded, err := LoadLearner(bytes.NewReader(v1)); err != nil || loaded.Game != "" || loaded.Len() != l.Len() {
+		t.Errorf("LoadLearner failed for version 1. Returned %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/er4z0r/tictacgo/games"
+)
+
+//DefaultIterations is the number of playouts of an MCTS without any budget
+const DefaultIterations = 1000
+
+//DefaultMaxPlayout is the number of plies after which a playout of an
+//MCTS without MaxPlayout is rated as a draw
+const DefaultMaxPlayout = 500
+
+//MCTS is a Monte Carlo tree search using UCT and random playouts.
+//It works with any GameLogic, but only the plies a game was played with
+//are copied to the workers, so games have to be conducted using Apply.
+//
+//The search ends after Iterations playouts or once Duration has passed,
+//whichever happens first. Without both DefaultIterations playouts are run.
+//
+//With NewGame set, the playouts are run concurrently by Workers goroutines,
+//each setting up the start position and replaying the history on its own
+//game returned by NewGame and
+//building its own tree (root parallelization). Otherwise the search runs
+//on the game itself.
+type MCTS struct {
+	Iterations int
+	Duration   time.Duration
+	Workers    int
+	//NewGame returns a game in its initial state, played by the same players
+	NewGame func() (games.GameLogic, error)
+	//Exploration is the UCT exploration constant, math.Sqrt2 if 0
+	Exploration float64
+	//MaxPlayout is the number of plies after which a playout is rated as
+	//a draw, e.g. for games that can go on forever. DefaultMaxPlayout if 0.
+	MaxPlayout int
+	//Seed initializes the random playouts. The current time is used if 0.
+	Seed int64
+}
+
+//MoveStats are the results of the playouts that started with Ply
+type MoveStats struct {
+	Ply    games.Ply
+	Visits int
+	//Wins counts won playouts as 1 and draws as 0.5
+	Wins float64
+}
+
+//WinRate returns the share of playouts won by the player of the ply
+func (s MoveStats) WinRate() float64 {
+	if s.Visits == 0 {
+		return 0
+	}
+	return s.Wins / float64(s.Visits)
+}
+
+func (s MoveStats) String() string {
+	return fmt.Sprintf("%v: %d visits, %.1f%% won", s.Ply, s.Visits, 100*s.WinRate())
+}
+
+//BestMove implements the Agent interface
+func (m *MCTS) BestMove(g games.GameLogic) (games.Ply, error) {
+	stats, err := m.Search(context.Background(), g)
+	if err != nil {
+		return games.Ply{}, err
+	}
+	return stats[0].Ply, nil
+}
+
+//Search runs the playouts until the budget is used up or ctx is done.
+//It returns the statistics of all plies of the player whose turn it is,
+//ordered by the number of visits. The first one is the chosen ply.
+func (m *MCTS) Search(ctx context.Context, g games.GameLogic) ([]MoveStats, error) {
+	if _, err := legalMoves(g); err != nil {
+		return nil, err
+	}
+	if m.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.Duration)
+		defer cancel()
+	}
+	iterations := m.Iterations
+	if iterations == 0 && m.Duration == 0 {
+		iterations = DefaultIterations
+	}
+	seed := m.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	workers := 1
+	if m.NewGame != nil {
+		workers = m.Workers
+		if workers < 1 {
+			workers = runtime.NumCPU()
+		}
+	}
+
+	roots := make([]*node, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range roots {
+		//every worker runs its share of the iterations, 0 runs until ctx is done
+		n := iterations / workers
+		if i < iterations%workers {
+			n++
+		}
+		if iterations > 0 && n == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(i, n int) {
+			defer wg.Done()
+			game := g
+			if m.NewGame != nil {
+				if game, errs[i] = m.clone(g); errs[i] != nil {
+					return
+				}
+			}
+			roots[i] = m.run(ctx, game, n, rand.New(rand.NewSource(seed+int64(i))))
+		}(i, n)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	stats := merge(roots)
+	if len(stats) == 0 {
+		//the budget did not suffice for a single playout
+		moves, _ := legalMoves(g)
+		for _, mv := range moves {
+			stats = append(stats, MoveStats{Ply: mv})
+		}
+	}
+	return stats, nil
+}
+
+//clone returns a new game in the start position of g with all plies of g
+//replayed
+func (m *MCTS) clone(g games.GameLogic) (games.GameLogic, error) {
+	return rebuild(m.NewGame, g, len(g.History()))
+}
+
+//node is a node of the search tree. It is reached by conducting ply.
+type node struct {
+	ply      games.Ply
+	parent   *node
+	children []*node
+	untried  []games.Ply
+	visits   int
+	wins     float64
+}
+
+//newNode returns a node for the current state of g reached by ply m
+func newNode(g games.GameLogic, parent *node, m games.Ply) *node {
+	n := &node{ply: m, parent: parent}
+	if !g.IsOver() {
+		n.untried = g.LegalMoves(g.WhoseTurn())
+	}
+	return n
+}
+
+//run conducts n iterations on g, or runs until ctx is done if n is 0,
+//and returns the root of the search tree
+func (m *MCTS) run(ctx context.Context, g games.GameLogic, n int, r *rand.Rand) *node {
+	root := newNode(g, nil, games.Ply{})
+	for i := 0; n == 0 || i < n; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		m.iterate(g, root, r)
+	}
+	return root
+}
+
+//iterate selects a leaf of the tree starting at root, expands it, runs a
+//random playout and propagates the result back to root. g is left unchanged.
+func (m *MCTS) iterate(g games.GameLogic, root *node, r *rand.Rand) {
+	applied := 0
+	defer func() {
+		for ; applied > 0; applied-- {
+			g.Undo()
+		}
+	}()
+
+	//selection
+	n := root
+	for len(n.untried) == 0 && len(n.children) > 0 {
+		n = m.selectChild(n)
+		if g.Apply(n.ply) != nil {
+			return
+		}
+		applied++
+	}
+
+	//expansion
+	if len(n.untried) > 0 {
+		i := r.Intn(len(n.untried))
+		ply := n.untried[i]
+		n.untried = append(n.untried[:i], n.untried[i+1:]...)
+		if g.Apply(ply) != nil {
+			return
+		}
+		applied++
+		child := newNode(g, n, ply)
+		n.children = append(n.children, child)
+		n = child
+	}
+
+	//playout
+	max := m.MaxPlayout
+	if max == 0 {
+		max = DefaultMaxPlayout
+	}
+	var winner *games.Player
+	for i := 0; ; i++ {
+		if g.IsOver() {
+			winner = g.GetWinner()
+			break
+		}
+		moves := g.LegalMoves(g.WhoseTurn())
+		if len(moves) == 0 || i == max {
+			break
+		}
+		if g.Apply(moves[r.Intn(len(moves))]) != nil {
+			break
+		}
+		applied++
+	}
+
+	//backpropagation
+	for ; n != nil; n = n.parent {
+		n.visits++
+		switch {
+		case winner == nil:
+			n.wins += 0.5
+		case n.ply.Player != nil && *winner == *n.ply.Player:
+			n.wins++
+		}
+	}
+}
+
+//selectChild returns the child of n with the highest upper confidence bound
+func (m *MCTS) selectChild(n *node) *node {
+	c := m.Exploration
+	if c == 0 {
+		c = math.Sqrt2
+	}
+	var best *node
+	bestValue := math.Inf(-1)
+	logN := math.Log(float64(n.visits))
+	for _, child := range n.children {
+		v := child.wins/float64(child.visits) + c*math.Sqrt(logN/float64(child.visits))
+		if v > bestValue {
+			best, bestValue = child, v
+		}
+	}
+	return best
+}
+
+//merge sums up the statistics of the children of all roots
+func merge(roots []*node) []MoveStats {
+	var stats []MoveStats
+	index := make(map[string]int)
+	for _, root := range roots {
+		if root == nil {
+			continue
+		}
+		for _, child := range root.children {
+			key := fmt.Sprint(child.ply.Action, child.ply.Coords)
+			i, ok := index[key]
+			if !ok {
+				i = len(stats)
+				index[key] = i
+				stats = append(stats, MoveStats{Ply: child.ply})
+			}
+			stats[i].Visits += child.visits
+			stats[i].Wins += child.wins
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Visits > stats[j].Visits
+	})
+	return stats
+}
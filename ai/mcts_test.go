@@ -0,0 +1,120 @@
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/er4z0r/tictacgo/games"
+)
+
+func TestMCTSWins(t *testing.T) {
+	//o|o|
+	//x|x|
+	// | |
+	b, g, p1, _ := ticTacToe(t, []int{0, 0, 1, 0}, []int{0, 1, 1, 1})
+	m, err := (&MCTS{Iterations: 2000, Seed: 1}).BestMove(g)
+	if err != nil || m.Player != p1 || m.Coords[0] != 2 || m.Coords[1] != 0 {
+		t.Errorf("BestMove failed. Returned %v (%v) expected the win at 2,0", m, err)
+	}
+	if !b.IsEmpty(2, 0) || len(g.History()) != 0 {
+		t.Errorf("BestMove failed. The game was changed by the search\n%v", b)
+	}
+}
+
+func TestMCTSStats(t *testing.T) {
+	_, g, _, _ := ticTacToe(t, nil, nil)
+	stats, err := (&MCTS{Iterations: 900, Seed: 1}).Search(context.Background(), g)
+	if err != nil || len(stats) != 9 {
+		t.Fatalf("Search failed. Returned %v (%v)", stats, err)
+	}
+	visits := 0
+	for i, s := range stats {
+		visits += s.Visits
+		if i > 0 && s.Visits > stats[i-1].Visits {
+			t.Errorf("Search failed. The statistics are not ordered by visits: %v", stats)
+		}
+		if s.WinRate() < 0 || s.WinRate() > 1 {
+			t.Errorf("Search failed. Invalid win rate %v", s)
+		}
+	}
+	if visits != 900 {
+		t.Errorf("Search failed. Counted %d visits expected: %d", visits, 900)
+	}
+}
+
+func TestMCTSConcurrent(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	newGame := func() (GameLogic, error) {
+		b, _ := NewSimple2DBoard(7, 7)
+		return NewGomokuLogic(b, Freestyle, p1, p2)
+	}
+	g, _ := newGame()
+	//o has four in a row blocked at one end, x has an open three
+	for _, c := range [][2]int{{1, 3}, {0, 3}, {2, 3}, {1, 5}, {3, 3}, {2, 5}, {4, 3}, {3, 5}} {
+		if err := g.Apply(NewPly(Place, g.WhoseTurn(), c[0], c[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := &MCTS{Iterations: 1000, Workers: 4, NewGame: newGame, Seed: 1}
+	stats, err := m.Search(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Search failed. %v", err)
+	}
+	if c := stats[0].Ply.Coords; stats[0].Ply.Player != p1 || c[0] != 5 || c[1] != 3 {
+		t.Errorf("Search failed. Chose %v instead of completing five", stats[0])
+	}
+	if len(g.History()) != 8 {
+		t.Errorf("Search failed. The game was changed by the search")
+	}
+}
+
+func TestMCTSSetUp(t *testing.T) {
+	//o|o|
+	//x|x|
+	// | |
+	g, _ := newTicTacToe()
+	start, _, err := ParsePosition("oo./xx./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetUp(g, start); err != nil {
+		t.Fatal(err)
+	}
+	//the workers have to start from the set up pieces, too
+	m, err := (&MCTS{Iterations: 500, Workers: 2, NewGame: newTicTacToe, Seed: 1}).BestMove(g)
+	if err != nil || m.Coords[0] != 2 || m.Coords[1] != 0 {
+		t.Errorf("BestMove failed. Returned %v (%v) expected the win at 2,0", m, err)
+	}
+}
+
+func TestMCTSDeadline(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	newGame := func() (GameLogic, error) {
+		b, _ := NewSimple2DBoard(15, 15)
+		return NewGomokuLogic(b, Freestyle, p1, p2)
+	}
+	g, _ := newGame()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	stats, err := (&MCTS{Workers: 2, NewGame: newGame}).Search(ctx, g)
+	if err != nil || len(stats) == 0 {
+		t.Fatalf("Search failed. Returned %v (%v)", stats, err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Search failed. Ignored the deadline and took %v", d)
+	}
+
+	start = time.Now()
+	if _, err := (&MCTS{Duration: 50 * time.Millisecond}).BestMove(g); err != nil {
+		t.Errorf("BestMove failed. %v", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("BestMove failed. Ignored the duration and took %v", d)
+	}
+}
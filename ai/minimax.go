@@ -0,0 +1,204 @@
+package ai
+
+import "github.com/er4z0r/tictacgo/games"
+
+//WinScore is the rating of a won game. Wins after fewer plies are rated
+//higher, so it has to exceed the rating of any unfinished game.
+const WinScore = 1 << 20
+
+//Minimax searches the game tree with minimax and alpha-beta pruning.
+//With a Depth of 0 the whole tree is searched, which results in perfect
+//play but is only feasible for small games like tic-tac-toe. Otherwise
+//the search stops after Depth plies and rates the game using Evaluate.
+//
+//Games implementing games.Hasher are looked up in Table, if it is set,
+//so positions reached by different orders of plies are only searched once.
+type Minimax struct {
+	Depth    int
+	Evaluate Evaluator
+	Table    *TranspositionTable
+}
+
+//unlimited is the depth stored in the transposition table for positions
+//searched to the end of the game
+const unlimited = 1 << 30
+
+//DefaultTableSize is the number of entries of the transposition table
+//of a Minimax returned by NewMinimax
+const DefaultTableSize = 1 << 18
+
+//NewMinimax returns a Minimax searching depth plies ahead, rating
+//unfinished games using ScoreEvaluator and remembering searched positions
+func NewMinimax(depth int) *Minimax {
+	return &Minimax{Depth: depth, Evaluate: ScoreEvaluator, Table: NewTranspositionTable(DefaultTableSize)}
+}
+
+//BestMove implements the Agent interface.
+//Of several equally good plies the first one returned by LegalMoves is chosen.
+func (m *Minimax) BestMove(g games.GameLogic) (games.Ply, error) {
+	moves, err := legalMoves(g)
+	if err != nil {
+		return games.Ply{}, err
+	}
+	me := g.WhoseTurn()
+	best, alpha := moves[0], -WinScore-1
+	for _, mv := range moves {
+		if err := g.Apply(mv); err != nil {
+			return games.Ply{}, err
+		}
+		v := m.search(g, me, 1, alpha, WinScore+1)
+		g.Undo()
+		if v > alpha {
+			best, alpha = mv, v
+		}
+	}
+	return best, nil
+}
+
+//RatedMove is a ply together with its minimax value for its player
+type RatedMove struct {
+	Ply   games.Ply
+	Value int
+}
+
+//Rate returns the minimax value of every legal ply of the player whose turn
+//it is. Unlike BestMove it does not cut off plies that are worse than the
+//best one, so it is slower but allows to tell all equally good plies apart.
+func (m *Minimax) Rate(g games.GameLogic) ([]RatedMove, error) {
+	moves, err := legalMoves(g)
+	if err != nil {
+		return nil, err
+	}
+	me := g.WhoseTurn()
+	rated := make([]RatedMove, 0, len(moves))
+	for _, mv := range moves {
+		if err := g.Apply(mv); err != nil {
+			return nil, err
+		}
+		rated = append(rated, RatedMove{mv, m.search(g, me, 1, -WinScore-1, WinScore+1)})
+		g.Undo()
+	}
+	return rated, nil
+}
+
+//search returns the minimax value of the game for player me after the
+//given number of plies. Values outside of alpha and beta are cut off.
+func (m *Minimax) search(g games.GameLogic, me *games.Player, ply, alpha, beta int) int {
+	h, hashed := g.(games.Hasher)
+	hashed = hashed && m.Table != nil
+	var hash uint64
+	remaining := unlimited
+	if m.Depth > 0 {
+		remaining = m.Depth - ply
+	}
+	if hashed {
+		hash = h.Hash()
+		if e, ok := m.Table.Get(hash, me.Symbol); ok && e.Depth >= remaining {
+			v := fromTable(e.Value, ply)
+			switch {
+			case e.Bound == Exact:
+				return v
+			case e.Bound == Lower && v > alpha:
+				alpha = v
+			case e.Bound == Upper && v < beta:
+				beta = v
+			}
+			if alpha >= beta {
+				return v
+			}
+		}
+	}
+
+	v := m.value(g, me, ply, alpha, beta)
+	if hashed {
+		e := Entry{Depth: remaining, Value: toTable(v, ply), Bound: Exact}
+		if v <= alpha {
+			e.Bound = Upper
+		} else if v >= beta {
+			e.Bound = Lower
+		}
+		m.Table.Put(hash, me.Symbol, e)
+	}
+	return v
+}
+
+//value computes the minimax value of search without using the table
+func (m *Minimax) value(g games.GameLogic, me *games.Player, ply, alpha, beta int) int {
+	if g.IsOver() {
+		return rate(g.GetWinner(), me, ply)
+	}
+	if m.Depth > 0 && ply >= m.Depth {
+		return m.evaluate(g, me)
+	}
+	p := g.WhoseTurn()
+	moves := g.LegalMoves(p)
+	if len(moves) == 0 {
+		return rate(g.GetWinner(), me, ply)
+	}
+
+	maximize := *p == *me
+	for _, mv := range moves {
+		if err := g.Apply(mv); err != nil {
+			continue
+		}
+		v := m.search(g, me, ply+1, alpha, beta)
+		g.Undo()
+		if maximize && v > alpha {
+			alpha = v
+		} else if !maximize && v < beta {
+			beta = v
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	if maximize {
+		return alpha
+	}
+	return beta
+}
+
+//toTable converts the value of a position after the given number of
+//plies to a value independent of the plies that lead to it. Wins and
+//losses are stored relative to the position instead of the root.
+func toTable(v, ply int) int {
+	switch {
+	case v > WinScore/2:
+		return v + ply
+	case v < -WinScore/2:
+		return v - ply
+	}
+	return v
+}
+
+//fromTable reverts toTable for a position after the given number of plies
+func fromTable(v, ply int) int {
+	switch {
+	case v > WinScore/2:
+		return v - ply
+	case v < -WinScore/2:
+		return v + ply
+	}
+	return v
+}
+
+//evaluate rates an unfinished game for player me
+func (m *Minimax) evaluate(g games.GameLogic, me *games.Player) int {
+	if m.Evaluate == nil {
+		return 0
+	}
+	return m.Evaluate(g, me)
+}
+
+//rate returns the value of a finished game with winner w for player me
+//after the given number of plies
+func rate(w, me *games.Player, ply int) int {
+	switch {
+	case w == nil:
+		return 0
+	case *w == *me:
+		return WinScore - ply
+	default:
+		return -WinScore + ply
+	}
+}
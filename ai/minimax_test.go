@@ -0,0 +1,154 @@
+package ai
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/er4z0r/tictacgo/games"
+)
+
+//ticTacToe returns a fresh tic-tac-toe game with the given pieces
+//of the first and second player already placed
+func ticTacToe(t *testing.T, first, second []int) (*Simple2DBoard, GameLogic, *Player, *Player) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	b, _ := NewSimple2DBoard(3, 3)
+	g, err := NewBaseLogic(b, p1, p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i+1 < len(first); i += 2 {
+		b.Set(first[i], first[i+1], p1.Symbol)
+	}
+	for i := 0; i+1 < len(second); i += 2 {
+		b.Set(second[i], second[i+1], p2.Symbol)
+	}
+	return b, g, p1, p2
+}
+
+func TestMinimaxWins(t *testing.T) {
+	//o|o|
+	//x|x|
+	// | |
+	b, g, p1, _ := ticTacToe(t, []int{0, 0, 1, 0}, []int{0, 1, 1, 1})
+	m, err := NewMinimax(0).BestMove(g)
+	if err != nil || m.Player != p1 || m.Coords[0] != 2 || m.Coords[1] != 0 {
+		t.Errorf("BestMove failed. Returned %v (%v) expected the win at 2,0", m, err)
+	}
+	if !b.IsEmpty(2, 0) || len(g.History()) != 0 {
+		t.Errorf("BestMove failed. The game was changed by the search\n%v", b)
+	}
+}
+
+func TestMinimaxBlocks(t *testing.T) {
+	//o|o|
+	// |x|
+	// | |
+	_, g, _, p2 := ticTacToe(t, []int{0, 0, 1, 0}, []int{1, 1})
+	m, err := NewMinimax(0).BestMove(g)
+	if err != nil || m.Player != p2 || m.Coords[0] != 2 || m.Coords[1] != 0 {
+		t.Errorf("BestMove failed. Returned %v (%v) expected the block at 2,0", m, err)
+	}
+}
+
+func TestMinimaxPerfectPlay(t *testing.T) {
+	b, g, _, _ := ticTacToe(t, nil, nil)
+	var a Agent = NewMinimax(0)
+	for !g.IsOver() {
+		m, err := a.BestMove(g)
+		if err != nil {
+			t.Fatalf("BestMove failed. %v", err)
+		}
+		if err := g.Apply(m); err != nil {
+			t.Fatalf("BestMove failed. Returned the illegal ply %v: %v", m, err)
+		}
+	}
+	if w := g.GetWinner(); w != nil || len(g.History()) != 9 {
+		t.Errorf("Perfect play has to end in a draw. %v won\n%v", w, b)
+	}
+	if _, err := a.BestMove(g); err != ErrGameOver {
+		t.Errorf("BestMove failed. Expected ErrGameOver, got %v", err)
+	}
+}
+
+func TestMinimaxDepthLimit(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	b, _ := NewSimple2DBoard(7, 6)
+	g, _ := NewConnectFourLogic(b, p1, p2)
+	for _, x := range []int{3, 0, 3, 0, 3} {
+		g.Apply(NewPly(Place, g.WhoseTurn(), x))
+	}
+
+	//x has to block the column o is about to complete
+	m, err := NewMinimax(4).BestMove(g)
+	if err != nil || m.Player != p2 || m.Coords[0] != 3 {
+		t.Errorf("BestMove failed. Returned %v (%v) expected the block in column 3", m, err)
+	}
+	if len(g.History()) != 5 {
+		t.Errorf("BestMove failed. The history was changed by the search")
+	}
+}
+
+func TestScoreEvaluator(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	b, _ := NewSimple2DBoard(8, 8)
+	g, _ := NewOthelloLogic(b, p1, p2)
+	if v := ScoreEvaluator(g, p1); v != 0 {
+		t.Errorf("ScoreEvaluator failed. Returned %d expected: %d", v, 0)
+	}
+	m, _ := NewMinimax(1).BestMove(g)
+	g.Apply(m)
+	if v := ScoreEvaluator(g, m.Player); v != 3 {
+		t.Errorf("ScoreEvaluator failed. Returned %d expected: %d", v, 3)
+	}
+
+	_, ttt, _, _ := ticTacToe(t, nil, nil)
+	if v := ScoreEvaluator(ttt, p1); v != 0 {
+		t.Errorf("ScoreEvaluator failed for a game without score. Returned %d", v)
+	}
+}
+
+func TestMinimaxTranspositionTable(t *testing.T) {
+	with, without := NewMinimax(0), NewMinimax(0)
+	without.Table = nil
+
+	//both searches have to rate every reply to every opening equally
+	_, g, _, _ := ticTacToe(t, nil, nil)
+	for _, opening := range g.LegalMoves(g.WhoseTurn()) {
+		g.Apply(opening)
+		a, err := with.BestMove(g)
+		e, _ := without.BestMove(g)
+		if err != nil || !reflect.DeepEqual(a.Coords, e.Coords) {
+			t.Errorf("BestMove failed after %v. Returned %v expected: %v", opening, a, e)
+		}
+		g.Undo()
+	}
+	if with.Table.Len() == 0 {
+		t.Errorf("BestMove failed. No positions were stored in the table")
+	}
+}
+
+func TestTranspositionTable(t *testing.T) {
+	tt := NewTranspositionTable(2)
+	tt.Put(1, "o", Entry{Depth: 3, Value: 5, Bound: Exact})
+	if e, ok := tt.Get(1, "o"); !ok || e.Value != 5 {
+		t.Errorf("Get failed. Returned %v (%t)", e, ok)
+	}
+	if _, ok := tt.Get(1, "x"); ok {
+		t.Errorf("Get failed. Returned the entry of another player")
+	}
+
+	//shallower searches do not replace deeper ones
+	tt.Put(1, "o", Entry{Depth: 1, Value: 7, Bound: Lower})
+	if e, _ := tt.Get(1, "o"); e.Value != 5 {
+		t.Errorf("Put failed. Replaced a deeper entry by %v", e)
+	}
+
+	tt.Put(2, "o", Entry{Depth: 1})
+	tt.Put(3, "o", Entry{Depth: 1})
+	if tt.Len() != 1 {
+		t.Errorf("Put failed. The table holds %d entries expected: %d", tt.Len(), 1)
+	}
+}
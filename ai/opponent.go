@@ -0,0 +1,250 @@
+package ai
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/er4z0r/tictacgo/games"
+)
+
+//Level is the playing strength of an Opponent
+type Level int
+
+const (
+	Easy Level = iota
+	Medium
+	Hard
+)
+
+func (l Level) String() string {
+	switch l {
+	case Easy:
+		return "easy"
+	case Medium:
+		return "medium"
+	default:
+		return "hard"
+	}
+}
+
+//Style decides which of several equally good plies an Opponent prefers
+type Style int
+
+const (
+	//Balanced picks any of the best plies
+	Balanced Style = iota
+	//Blocking prefers plies close to the pieces of the opponent
+	Blocking
+	//Central prefers plies close to the center of the board
+	Central
+)
+
+func (s Style) String() string {
+	switch s {
+	case Blocking:
+		return "blocking"
+	case Central:
+		return "central"
+	default:
+		return "balanced"
+	}
+}
+
+//Opponent is a configurable computer player. It conducts a random legal
+//ply with probability BlunderRate and otherwise the ply chosen by Agent.
+//If Agent is a Minimax, Style decides between equally good plies.
+//Opponents with the same Seed play the same plies in the same games.
+type Opponent struct {
+	Agent       Agent
+	BlunderRate float64
+	Style       Style
+	//Seed initializes the random choices. The current time is used if 0.
+	Seed int64
+	rnd  *rand.Rand
+}
+
+//NewOpponent returns an Opponent of level l searching with a Minimax.
+//depth is the search depth of a Hard opponent, 0 searches the whole game.
+//Easy opponents only look one ply ahead and blunder often, Medium ones
+//look two plies ahead and blunder rarely, Hard ones never blunder.
+func NewOpponent(l Level, depth int, s Style, seed int64) *Opponent {
+	o := &Opponent{Style: s, Seed: seed}
+	switch l {
+	case Easy:
+		o.BlunderRate, depth = 0.3, 1
+	case Medium:
+		o.BlunderRate = 0.1
+		if depth == 0 || depth > 2 {
+			depth = 2
+		}
+	}
+	o.Agent = NewMinimax(depth)
+	return o
+}
+
+//random returns the source of the random choices of o
+func (o *Opponent) random() *rand.Rand {
+	if o.rnd == nil {
+		seed := o.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		o.rnd = rand.New(rand.NewSource(seed))
+	}
+	return o.rnd
+}
+
+//BestMove implements the Agent interface
+func (o *Opponent) BestMove(g games.GameLogic) (games.Ply, error) {
+	moves, err := legalMoves(g)
+	if err != nil {
+		return games.Ply{}, err
+	}
+	r := o.random()
+	if r.Float64() < o.BlunderRate {
+		return moves[r.Intn(len(moves))], nil
+	}
+	m, ok := o.Agent.(*Minimax)
+	if !ok {
+		return o.Agent.BestMove(g)
+	}
+
+	rated, err := m.Rate(g)
+	if err != nil {
+		return games.Ply{}, err
+	}
+	var best []games.Ply
+	bestValue := 0
+	for _, rm := range rated {
+		v := rm.Value
+		if len(best) == 0 || v > bestValue {
+			best, bestValue = nil, v
+		}
+		if v == bestValue {
+			best = append(best, rm.Ply)
+		}
+	}
+	return o.prefer(g, best, r), nil
+}
+
+//prefer returns the ply of moves preferred by the style of o. Ties are
+//broken randomly.
+func (o *Opponent) prefer(g games.GameLogic, moves []games.Ply, r *rand.Rand) games.Ply {
+	var min, max []int
+	if b, ok := games.BoardOf(g); ok {
+		min, max = []int{0, 0}, []int{b.Width() - 1, b.Height() - 1}
+	} else {
+		//the fields of the board are either still empty or in the
+		//history, so their bounding box spans the board
+		for _, p := range append(g.LegalMoves(g.WhoseTurn()), g.History()...) {
+			for i, c := range target(p) {
+				if i == len(min) {
+					min, max = append(min, c), append(max, c)
+				}
+				if c < min[i] {
+					min[i] = c
+				}
+				if c > max[i] {
+					max[i] = c
+				}
+			}
+		}
+	}
+	var opponents [][]int
+	if len(moves) > 0 {
+		opponents = opponentPieces(g, moves[0].Player)
+	}
+
+	var preferred []games.Ply
+	bestScore := 0
+	for _, m := range moves {
+		s := 0
+		switch o.Style {
+		case Central:
+			s = central(m, min, max)
+		case Blocking:
+			s = blocking(m, opponents)
+		}
+		if len(preferred) == 0 || s > bestScore {
+			preferred, bestScore = nil, s
+		}
+		if s == bestScore {
+			preferred = append(preferred, m)
+		}
+	}
+	return preferred[r.Intn(len(preferred))]
+}
+
+//target returns the coordinates of the field ply m puts a piece on
+func target(m games.Ply) []int {
+	if m.Action == games.Move && len(m.Coords) == 4 {
+		return m.Coords[2:]
+	}
+	return m.Coords
+}
+
+//central rates ply m by the distance of its target to the center of the
+//box from min to max. Plies closer to the center are rated higher.
+func central(m games.Ply, min, max []int) int {
+	score := 0
+	for i, c := range target(m) {
+		if i < len(min) {
+			score -= abs(2*c - min[i] - max[i])
+		}
+	}
+	return score
+}
+
+//opponentPieces returns the fields holding pieces of the opponents of p.
+//They are read from the board of g, games without a 2D board take them
+//from the history.
+func opponentPieces(g games.GameLogic, p *games.Player) [][]int {
+	var fields [][]int
+	if b, ok := games.BoardOf(g); ok {
+		for y := 0; y < b.Height(); y++ {
+			for x := 0; x < b.Width(); x++ {
+				if s := b.Get(x, y); s != "" && s != p.Symbol {
+					fields = append(fields, []int{x, y})
+				}
+			}
+		}
+		return fields
+	}
+	for _, m := range g.History() {
+		if *m.Player != *p {
+			fields = append(fields, target(m))
+		}
+	}
+	return fields
+}
+
+//blocking rates ply m by the distance of its target to the closest of the
+//fields of opponent pieces. Closer plies are rated higher. Targets with
+//fewer coordinates than the fields, e.g. columns, are compared to their
+//first coordinates.
+func blocking(m games.Ply, opponents [][]int) int {
+	t := target(m)
+	score, found := 0, false
+	for _, f := range opponents {
+		if len(f) < len(t) {
+			continue
+		}
+		d := 0
+		for i := range t {
+			if a := abs(t[i] - f[i]); a > d {
+				d = a
+			}
+		}
+		if !found || -d > score {
+			score, found = -d, true
+		}
+	}
+	return score
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
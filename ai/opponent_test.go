@@ -0,0 +1,89 @@
+package ai
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/er4z0r/tictacgo/games"
+)
+
+func TestHardOpponentDraws(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		b, g, p1, _ := ticTacToe(t, nil, nil)
+		hard, perfect := NewOpponent(Hard, 0, Balanced, seed), NewMinimax(0)
+		for !g.IsOver() {
+			var a Agent = perfect
+			if g.WhoseTurn() == p1 {
+				a = hard
+			}
+			m, err := a.BestMove(g)
+			if err != nil {
+				t.Fatalf("BestMove failed. %v", err)
+			}
+			g.Apply(m)
+		}
+		if w := g.GetWinner(); w != nil {
+			t.Errorf("A hard opponent lost with seed %d\n%v", seed, b)
+		}
+	}
+}
+
+func TestOpponentSeed(t *testing.T) {
+	play := func(seed int64) string {
+		_, g, _, _ := ticTacToe(t, nil, nil)
+		o := &Opponent{Agent: NewMinimax(1), BlunderRate: 1, Seed: seed}
+		for !g.IsOver() {
+			m, err := o.BestMove(g)
+			if err != nil || g.Apply(m) != nil {
+				t.Fatalf("BestMove failed. Returned the illegal ply %v (%v)", m, err)
+			}
+		}
+		return fmt.Sprint(g.History())
+	}
+	if a, e := play(7), play(7); a != e {
+		t.Errorf("BestMove failed. Opponents with the same seed played\n%v\nand\n%v", a, e)
+	}
+}
+
+func TestOpponentStyles(t *testing.T) {
+	//every first ply leads to a draw, so the style decides
+	_, g, _, _ := ticTacToe(t, nil, nil)
+	m, err := NewOpponent(Hard, 0, Central, 1).BestMove(g)
+	if err != nil || m.Coords[0] != 1 || m.Coords[1] != 1 {
+		t.Errorf("BestMove failed. A central opponent played %v (%v)", m, err)
+	}
+
+	//x|o|
+	// | |
+	// | |
+	_, g, _, p2 := ticTacToe(t, nil, nil)
+	g.Apply(NewPly(Place, g.WhoseTurn(), 2, 2))
+	for seed := int64(1); seed <= 5; seed++ {
+		m, err := NewOpponent(Medium, 1, Blocking, seed).BestMove(g)
+		if err != nil || m.Player != p2 || m.Coords[0] < 1 || m.Coords[1] < 1 {
+			t.Errorf("BestMove failed. A blocking opponent played %v (%v)", m, err)
+		}
+	}
+
+	//pieces that are not in the history count as well
+	_, g, _, p2 = ticTacToe(t, []int{2, 2}, nil)
+	for seed := int64(1); seed <= 5; seed++ {
+		m, err := NewOpponent(Medium, 1, Blocking, seed).BestMove(g)
+		if err != nil || m.Player != p2 || m.Coords[0] < 1 || m.Coords[1] < 1 {
+			t.Errorf("BestMove failed. A blocking opponent played %v on a set up board (%v)", m, err)
+		}
+	}
+	_, g, _, p2 = ticTacToe(t, nil, nil)
+	g.Apply(NewPly(Place, g.WhoseTurn(), 2, 2))
+
+	rated, err := NewMinimax(0).Rate(g)
+	if err != nil || len(rated) != 8 {
+		t.Fatalf("Rate failed. Returned %v (%v)", rated, err)
+	}
+	for _, rm := range rated {
+		//only the center avoids losing against a corner opening
+		if center := rm.Ply.Coords[0] == 1 && rm.Ply.Coords[1] == 1; center != (rm.Value == 0) {
+			t.Errorf("Rate failed. Rated %v with %d", rm.Ply, rm.Value)
+		}
+	}
+}
@@ -0,0 +1,455 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/er4z0r/tictacgo/games"
+)
+
+//MaxSolverFields is the largest number of fields of a board Solve accepts
+const MaxSolverFields = 20
+
+//Errors returned by a Table
+var (
+	ErrUnknownPosition = errors.New("the position is not in the table")
+	ErrTableSize       = errors.New("the board does not match the table")
+)
+
+//Result is the outcome of a game under perfect play from the point of view
+//of the player whose turn it is
+type Result int
+
+const (
+	Draw Result = iota
+	Win
+	Loss
+)
+
+func (r Result) String() string {
+	switch r {
+	case Win:
+		return "win"
+	case Loss:
+		return "loss"
+	default:
+		return "draw"
+	}
+}
+
+//Value is the theoretical value of a position: its Result and the number
+//of plies until the game ends under perfect play. The winner wins as fast
+//as possible and the loser delays the loss as long as possible.
+type Value struct {
+	Result   Result
+	Distance int
+}
+
+func (v Value) String() string {
+	return fmt.Sprintf("%v in %d plies", v.Result, v.Distance)
+}
+
+//encode packs v into a single byte: the result in the upper two bits and
+//the distance in the lower six
+func (v Value) encode() byte {
+	return byte(v.Result)<<6 | byte(v.Distance)
+}
+
+func decodeValue(b byte) Value {
+	return Value{Result: Result(b >> 6), Distance: int(b & 0x3f)}
+}
+
+//better returns true, if moving into a position with value a is better for
+//the player to move than moving into one with value b. Both values are from
+//the point of view of the opponent.
+func better(a, b Value) bool {
+	rank := func(v Value) int {
+		switch v.Result {
+		case Loss:
+			return 2<<8 - v.Distance
+		case Draw:
+			return 1 << 8
+		default:
+			return v.Distance
+		}
+	}
+	return rank(a) > rank(b)
+}
+
+//Table holds the values of all positions of an m,n,k-game reachable from
+//the empty board, as played by games.BaseLogic: the first player moves
+//first and k or more pieces in a row, column or diagonal win.
+//Positions are stored once for all their symmetric equivalents.
+type Table struct {
+	M, N, K int
+	keys    []uint64
+	values  []byte
+	//syms maps the fields of every symmetry of the board
+	syms [][]int
+	//lines holds for every field the lines of k fields through it
+	lines [][][]int
+}
+
+func newTable(m, n, k int) (*Table, error) {
+	if m < 1 || n < 1 || k < 1 {
+		return nil, fmt.Errorf("the dimensions and the win length must be positive")
+	}
+	if m*n > MaxSolverFields {
+		return nil, fmt.Errorf("a board with %d fields is too large to be solved", m*n)
+	}
+	t := &Table{M: m, N: n, K: k}
+	for _, s := range games.Symmetries {
+		if w, h := s.Size(m, n); w != m || h != n {
+			continue
+		}
+		perm := make([]int, m*n)
+		for i := range perm {
+			x, y := s.Apply(i%m, i/m, m, n)
+			perm[i] = y*m + x
+		}
+		t.syms = append(t.syms, perm)
+	}
+	t.lines = make([][][]int, m*n)
+	for y := 0; y < n; y++ {
+		for x := 0; x < m; x++ {
+			for _, d := range [][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}} {
+				ex, ey := x+(k-1)*d[0], y+(k-1)*d[1]
+				if ex < 0 || ey < 0 || ex >= m || ey >= n {
+					continue
+				}
+				line := make([]int, k)
+				for i := range line {
+					line[i] = (y+i*d[1])*m + x + i*d[0]
+				}
+				for _, f := range line {
+					t.lines[f] = append(t.lines[f], line)
+				}
+			}
+		}
+	}
+	return t, nil
+}
+
+//position is a board of the solver: 0 for empty fields, 1 for pieces of the
+//first and 2 for pieces of the second player
+type position []byte
+
+//key returns the canonical key of p, which is the same for all symmetric
+//positions
+func (t *Table) key(p position) uint64 {
+	var min uint64
+	for i, perm := range t.syms {
+		var k uint64
+		for f := len(p) - 1; f >= 0; f-- {
+			k = k*3 + uint64(p[perm[f]])
+		}
+		if i == 0 || k < min {
+			min = k
+		}
+	}
+	return min
+}
+
+//decode returns the position with key k
+func (t *Table) decode(k uint64) position {
+	p := make(position, t.M*t.N)
+	for f := range p {
+		p[f] = byte(k % 3)
+		k /= 3
+	}
+	return p
+}
+
+//completes returns true, if the piece at field f is part of k in a row
+func (t *Table) completes(p position, f int) bool {
+	for _, line := range t.lines[f] {
+		full := true
+		for _, g := range line {
+			if p[g] != p[f] {
+				full = false
+				break
+			}
+		}
+		if full {
+			return true
+		}
+	}
+	return false
+}
+
+//terminal returns the value of p, if the game is over
+func (t *Table) terminal(p position) (Value, bool) {
+	empty := false
+	for f, s := range p {
+		if s == 0 {
+			empty = true
+		} else if t.completes(p, f) {
+			//only the player who moved last can have completed a line
+			return Value{Result: Loss}, true
+		}
+	}
+	return Value{}, !empty
+}
+
+//toMove returns 1 or 2 for the player whose turn it is in p
+func toMove(p position) byte {
+	pieces := 0
+	for _, s := range p {
+		if s != 0 {
+			pieces++
+		}
+	}
+	return byte(pieces%2 + 1)
+}
+
+//Solve evaluates all positions of the m,n,k-game reachable from the empty
+//board by retrograde analysis: all positions are generated level by level,
+//ordered by the number of pieces, and then evaluated from the full board
+//back to the empty one, so the values of all successors are known.
+func Solve(m, n, k int) (*Table, error) {
+	t, err := newTable(m, n, k)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := m * n
+	levels := make([]map[uint64]bool, fields+1)
+	levels[0] = map[uint64]bool{t.key(make(position, fields)): true}
+	for l := 0; l < fields; l++ {
+		levels[l+1] = make(map[uint64]bool)
+		for key := range levels[l] {
+			p := t.decode(key)
+			if _, over := t.terminal(p); over {
+				continue
+			}
+			s := toMove(p)
+			for f := range p {
+				if p[f] == 0 {
+					p[f] = s
+					levels[l+1][t.key(p)] = true
+					p[f] = 0
+				}
+			}
+		}
+	}
+
+	values := make(map[uint64]byte)
+	for l := fields; l >= 0; l-- {
+		for key := range levels[l] {
+			p := t.decode(key)
+			v, over := t.terminal(p)
+			if !over {
+				v = t.bestChild(p, func(c uint64) Value { return decodeValue(values[c]) })
+				v = invert(v)
+			}
+			values[key] = v.encode()
+		}
+		levels[l] = nil
+	}
+
+	t.keys = make([]uint64, 0, len(values))
+	for key := range values {
+		t.keys = append(t.keys, key)
+	}
+	sort.Slice(t.keys, func(i, j int) bool { return t.keys[i] < t.keys[j] })
+	t.values = make([]byte, len(t.keys))
+	for i, key := range t.keys {
+		t.values[i] = values[key]
+	}
+	return t, nil
+}
+
+//invert returns the value of a position for the player to move, given the
+//value v of the best successor for the opponent
+func invert(v Value) Value {
+	switch v.Result {
+	case Loss:
+		v.Result = Win
+	case Win:
+		v.Result = Loss
+	}
+	v.Distance++
+	return v
+}
+
+//bestChild returns the value of the best successor of the unfinished
+//position p from the point of view of the opponent, looking up the values
+//of successors using value
+func (t *Table) bestChild(p position, value func(uint64) Value) Value {
+	best, found := Value{}, false
+	t.children(p, func(f int, v Value) {
+		if !found || better(v, best) {
+			best, found = v, true
+		}
+	}, value)
+	return best
+}
+
+//children calls visit for every empty field f of p with the value of the
+//position after the player to move placed a piece there
+func (t *Table) children(p position, visit func(f int, v Value), value func(uint64) Value) {
+	s := toMove(p)
+	for f := range p {
+		if p[f] == 0 {
+			p[f] = s
+			visit(f, value(t.key(p)))
+			p[f] = 0
+		}
+	}
+}
+
+//Len returns the number of stored positions
+func (t *Table) Len() int {
+	return len(t.keys)
+}
+
+//lookup returns the value stored for key
+func (t *Table) lookup(key uint64) (Value, error) {
+	i := sort.Search(len(t.keys), func(i int) bool { return t.keys[i] >= key })
+	if i == len(t.keys) || t.keys[i] != key {
+		return Value{}, ErrUnknownPosition
+	}
+	return decodeValue(t.values[i]), nil
+}
+
+//position converts board b to a position of the table. first is the symbol
+//of the player that moved first.
+func (t *Table) position(b games.Board, first string) (position, error) {
+	if b.Width() != t.M || b.Height() != t.N {
+		return nil, ErrTableSize
+	}
+	p := make(position, t.M*t.N)
+	firsts, seconds := 0, 0
+	for y := 0; y < t.N; y++ {
+		for x := 0; x < t.M; x++ {
+			switch s := b.Get(x, y); {
+			case s == first:
+				p[y*t.M+x] = 1
+				firsts++
+			case s != "":
+				p[y*t.M+x] = 2
+				seconds++
+			}
+		}
+	}
+	if firsts != seconds && firsts != seconds+1 {
+		return nil, ErrUnknownPosition
+	}
+	return p, nil
+}
+
+//Lookup returns the value of board b for the player whose turn it is.
+//first is the symbol of the player that moved first.
+func (t *Table) Lookup(b games.Board, first string) (Value, error) {
+	p, err := t.position(b, first)
+	if err != nil {
+		return Value{}, err
+	}
+	return t.lookup(t.key(p))
+}
+
+//BestReply returns the field the player whose turn it is on board b should
+//place a piece on. first is the symbol of the player that moved first.
+func (t *Table) BestReply(b games.Board, first string) (x, y int, err error) {
+	p, err := t.position(b, first)
+	if err != nil {
+		return 0, 0, err
+	}
+	v, err := t.lookup(t.key(p))
+	if err != nil {
+		return 0, 0, err
+	}
+	if v.Distance == 0 {
+		return 0, 0, ErrGameOver
+	}
+	best, bestField := Value{}, -1
+	t.children(p, func(f int, v Value) {
+		if bestField < 0 || better(v, best) {
+			best, bestField = v, f
+		}
+	}, func(key uint64) Value {
+		v, _ := t.lookup(key)
+		return v
+	})
+	return bestField % t.M, bestField / t.M, nil
+}
+
+//tableMagic identifies files written by Table.Save
+const tableMagic = "TTGS"
+
+//tableVersion is the version of the file format written by Table.Save
+const tableVersion = 1
+
+//Save writes the table to w. The keys are sorted, so only the differences
+//between successive keys are written as varints, followed by the value byte.
+func (t *Table) Save(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(tableMagic)
+	bw.Write([]byte{tableVersion, byte(t.M), byte(t.N), byte(t.K)})
+	buf := make([]byte, binary.MaxVarintLen64)
+	bw.Write(buf[:binary.PutUvarint(buf, uint64(len(t.keys)))])
+	var last uint64
+	for i, key := range t.keys {
+		bw.Write(buf[:binary.PutUvarint(buf, key-last)])
+		bw.WriteByte(t.values[i])
+		last = key
+	}
+	return bw.Flush()
+}
+
+//LoadTable reads a table written by Table.Save from r
+func LoadTable(r io.Reader) (*Table, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(tableMagic)+4)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, err
+	}
+	if string(header[:len(tableMagic)]) != tableMagic {
+		return nil, fmt.Errorf("not a solver table")
+	}
+	if v := header[len(tableMagic)]; v != tableVersion {
+		return nil, fmt.Errorf("unsupported table version %d", v)
+	}
+	dims := header[len(tableMagic)+1:]
+	t, err := newTable(int(dims[0]), int(dims[1]), int(dims[2]))
+	if err != nil {
+		return nil, err
+	}
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	//every field is empty or holds a piece of one of the players
+	positions := uint64(1)
+	for i := 0; i < t.M*t.N; i++ {
+		positions *= 3
+	}
+	if n > positions {
+		return nil, fmt.Errorf("the table holds %d positions, but a %dx%d board only has %d", n, t.M, t.N, positions)
+	}
+	//the slices grow while reading, so that a broken file does not
+	//allocate the memory of all positions in advance
+	c := n
+	if c > 1<<16 {
+		c = 1 << 16
+	}
+	t.keys, t.values = make([]uint64, 0, c), make([]byte, 0, c)
+	var last uint64
+	for i := uint64(0); i < n; i++ {
+		d, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		v, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		last += d
+		t.keys, t.values = append(t.keys, last), append(t.values, v)
+	}
+	return t, nil
+}
@@ -0,0 +1,162 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	. "github.com/er4z0r/tictacgo/games"
+)
+
+func TestSolveTicTacToe(t *testing.T) {
+	tb, err := Solve(3, 3, 3)
+	if err != nil {
+		t.Fatalf("Solve failed. %v", err)
+	}
+	//the number of reachable positions without symmetric duplicates
+	if tb.Len() != 765 {
+		t.Errorf("Solve failed. Stored %d positions expected: %d", tb.Len(), 765)
+	}
+
+	b, _, p1, _ := ticTacToe(t, nil, nil)
+	if v, err := tb.Lookup(b, p1.Symbol); err != nil || v != (Value{Draw, 9}) {
+		t.Errorf("Lookup failed for the empty board. Returned %v (%v)", v, err)
+	}
+
+	tests := []struct {
+		name          string
+		first, second []int
+		value         Value
+		x, y          int
+	}{
+		//o|o|
+		//x|x|
+		// | |
+		{"win", []int{0, 0, 1, 0}, []int{0, 1, 1, 1}, Value{Win, 1}, 2, 0},
+		//o|o|
+		// |x|
+		// | |
+		{"block", []int{0, 0, 1, 0}, []int{1, 1}, Value{Draw, 6}, 2, 0},
+		//o| |
+		// |x|
+		// | |o
+		{"fork", []int{0, 0, 2, 2}, []int{1, 1}, Value{Draw, 6}, 1, 0},
+		//o| |
+		// | |
+		// | |
+		{"corner", []int{0, 0}, nil, Value{Draw, 8}, 1, 1},
+		//o|o|o
+		//x|x|
+		// | |
+		{"over", []int{0, 0, 1, 0, 2, 0}, []int{0, 1, 1, 1}, Value{Loss, 0}, -1, -1},
+	}
+	for _, tc := range tests {
+		b, _, p1, _ := ticTacToe(t, tc.first, tc.second)
+		if v, err := tb.Lookup(b, p1.Symbol); err != nil || v != tc.value {
+			t.Errorf("Lookup failed for %s. Returned %v (%v) expected: %v", tc.name, v, err, tc.value)
+		}
+		x, y, err := tb.BestReply(b, p1.Symbol)
+		if tc.x < 0 {
+			if err != ErrGameOver {
+				t.Errorf("BestReply failed for %s. Expected ErrGameOver, got %v", tc.name, err)
+			}
+		} else if err != nil || x != tc.x || y != tc.y {
+			t.Errorf("BestReply failed for %s. Returned %d,%d (%v) expected: %d,%d", tc.name, x, y, err, tc.x, tc.y)
+		}
+	}
+}
+
+func TestSolveAgreesWithMinimax(t *testing.T) {
+	tb, _ := Solve(3, 3, 3)
+	b, g, p1, _ := ticTacToe(t, nil, nil)
+	m := NewMinimax(0)
+	for !g.IsOver() {
+		x, y, err := tb.BestReply(b, p1.Symbol)
+		if err != nil {
+			t.Fatalf("BestReply failed. %v", err)
+		}
+		e, _ := m.BestMove(g)
+		g.Apply(e)
+		v, _ := tb.Lookup(b, p1.Symbol)
+		g.Undo()
+		g.Apply(NewPly(Place, g.WhoseTurn(), x, y))
+		if a, _ := tb.Lookup(b, p1.Symbol); a != v {
+			t.Errorf("BestReply %d,%d leads to %v, but minimax reaches %v with %v", x, y, a, v, e)
+		}
+	}
+	if g.GetWinner() != nil {
+		t.Errorf("Perfect play has to end in a draw\n%v", b)
+	}
+}
+
+func TestTableSaveLoad(t *testing.T) {
+	tb, _ := Solve(3, 3, 3)
+	var buf bytes.Buffer
+	if err := tb.Save(&buf); err != nil {
+		t.Fatalf("Save failed. %v", err)
+	}
+	if buf.Len() > 4*tb.Len() {
+		t.Errorf("Save failed. %d bytes for %d positions are not compact", buf.Len(), tb.Len())
+	}
+	loaded, err := LoadTable(&buf)
+	if err != nil || !reflect.DeepEqual(loaded, tb) {
+		t.Errorf("LoadTable failed. %v", err)
+	}
+
+	if _, err := LoadTable(bytes.NewBufferString("PNG!")); err == nil {
+		t.Errorf("LoadTable failed. Accepted a file that is not a table")
+	}
+	//a 3x3 board has 3^9 positions at most
+	huge := append([]byte(tableMagic), tableVersion, 3, 3, 3)
+	huge = binary.AppendUvarint(huge, 1<<40)
+	if _, err := LoadTable(bytes.NewReader(huge)); err == nil {
+		t.Errorf("LoadTable failed. Accepted a table of more positions than the board has")
+	}
+	truncated := append([]byte(tableMagic), tableVersion, 3, 3, 3)
+	truncated = binary.AppendUvarint(truncated, 19683)
+	if _, err := LoadTable(bytes.NewReader(truncated)); err == nil {
+		t.Errorf("LoadTable failed. Accepted a truncated table")
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	if _, err := Solve(5, 5, 4); err == nil {
+		t.Errorf("Solve failed. Accepted a board that is too large")
+	}
+	tb, _ := Solve(3, 3, 3)
+	b, _ := NewSimple2DBoard(4, 4)
+	if _, err := tb.Lookup(b, "o"); err != ErrTableSize {
+		t.Errorf("Lookup failed. Expected ErrTableSize, got %v", err)
+	}
+	//the second player can not have more pieces than the first one
+	b, _, p1, _ := ticTacToe(t, nil, []int{0, 0})
+	if _, err := tb.Lookup(b, p1.Symbol); err != ErrUnknownPosition {
+		t.Errorf("Lookup failed. Expected ErrUnknownPosition, got %v", err)
+	}
+}
+
+func TestSolve4x4(t *testing.T) {
+	if testing.Short() {
+		t.Skip("solving 4x4 boards takes a while")
+	}
+	b, _ := NewSimple2DBoard(4, 4)
+	for _, tc := range []struct {
+		k     int
+		value Value
+	}{
+		//the first player forces three in a row with the third piece
+		{3, Value{Win, 5}},
+		//four in a row can always be blocked
+		{4, Value{Draw, 16}},
+	} {
+		tb, err := Solve(4, 4, tc.k)
+		if err != nil {
+			t.Fatalf("Solve failed for k=%d. %v", tc.k, err)
+		}
+		v, err := tb.Lookup(b, "o")
+		if err != nil || v != tc.value {
+			t.Errorf("Lookup failed for the empty board and k=%d. Returned %v (%v) expected: %v", tc.k, v, err, tc.value)
+		}
+	}
+}
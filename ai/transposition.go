@@ -0,0 +1,77 @@
+package ai
+
+import "sync"
+
+//Bound tells how the value of an Entry relates to the exact value
+//of a position
+type Bound int
+
+const (
+	//Exact: the value is the exact value of the position
+	Exact Bound = iota
+	//Lower: the exact value is at least the stored value
+	Lower
+	//Upper: the exact value is at most the stored value
+	Upper
+)
+
+//Entry is the result of searching a position
+type Entry struct {
+	//Depth is the number of plies searched below the position
+	Depth int
+	Value int
+	Bound Bound
+}
+
+//tableKey identifies a position rated from the point of view of a player
+type tableKey struct {
+	hash   uint64
+	player string
+}
+
+//TranspositionTable stores the results of searched positions, so positions
+//reached by different orders of plies are only searched once.
+//Positions are identified by their Zobrist hash, see games.Hasher.
+//It is safe for concurrent use.
+type TranspositionTable struct {
+	mu      sync.Mutex
+	entries map[tableKey]Entry
+	size    int
+}
+
+//NewTranspositionTable returns a table holding up to size entries.
+//Once it is full, it is cleared before storing the next entry.
+func NewTranspositionTable(size int) *TranspositionTable {
+	return &TranspositionTable{entries: make(map[tableKey]Entry), size: size}
+}
+
+//Get returns the entry of the position with the given hash rated from
+//the point of view of the player with the given symbol
+func (t *TranspositionTable) Get(hash uint64, player string) (Entry, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.entries[tableKey{hash, player}]
+	return e, ok
+}
+
+//Put stores the entry of a position, unless the table already holds
+//a deeper search of it
+func (t *TranspositionTable) Put(hash uint64, player string, e Entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	k := tableKey{hash, player}
+	if old, ok := t.entries[k]; ok && old.Depth > e.Depth {
+		return
+	}
+	if len(t.entries) >= t.size {
+		t.entries = make(map[tableKey]Entry)
+	}
+	t.entries[k] = e
+}
+
+//Len returns the number of stored entries
+func (t *TranspositionTable) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.entries)
+}
@@ -3,21 +3,52 @@ package games
 import "fmt"
 
 type BaseLogic struct {
+	history
 	players map[string]*Player
 	board   Board
 	stats   map[string]*PlayerStats
 	turn    int
+	//winLength is the number of pieces in a row needed to win (the k of an m,n,k-game)
+	winLength int
+	//misere is true, if completing a line loses the game
+	misere bool
 }
 
 type PlayerStats struct {
-	MovesMade int
-	Turn      int
+	MovesMade int `json:"movesMade"`
+	Turn      int `json:"turn"`
 }
 
-//NewBaseLogic returns an initialized BaseLogic struct
+//DefaultWinLength is the number of pieces in a row needed to win tic-tac-toe
+const DefaultWinLength = 3
+
+//NewBaseLogic returns an initialized BaseLogic struct that requires
+//DefaultWinLength pieces in a row to win
 func NewBaseLogic(b Board, players ...*Player) (*BaseLogic, error) {
+	return NewMNKLogic(b, DefaultWinLength, players...)
+}
+
+//NewMisereLogic returns an initialized BaseLogic struct for the misère
+//version of an m,n,k-game, where the player completing a line of k loses
+func NewMisereLogic(b Board, k int, players ...*Player) (*BaseLogic, error) {
+	bl, err := NewMNKLogic(b, k, players...)
+	if err != nil {
+		return nil, err
+	}
+	bl.misere = true
+	return bl, nil
+}
+
+//NewMNKLogic returns an initialized BaseLogic struct for an m,n,k-game,
+//where m and n are the dimensions of the board and k is the number of
+//pieces in a row (horizontally, vertically or diagonally) needed to win
+func NewMNKLogic(b Board, k int, players ...*Player) (*BaseLogic, error) {
 	bl := new(BaseLogic)
 
+	if k < 1 {
+		return nil, fmt.Errorf("the win length must be positive")
+	}
+
 	if players[0] == players[1] {
 		return nil, fmt.Errorf("you must supply two different players")
 	}
@@ -26,6 +57,7 @@ func NewBaseLogic(b Board, players ...*Player) (*BaseLogic, error) {
 	}
 
 	bl.board = b
+	bl.winLength = k
 	bl.players = make(map[string]*Player)
 	bl.stats = make(map[string]*PlayerStats)
 
@@ -37,6 +69,49 @@ func NewBaseLogic(b Board, players ...*Player) (*BaseLogic, error) {
 	return bl, nil
 }
 
+//Players implements the GameLogic interface
+func (bl *BaseLogic) Players() []*Player {
+	players := make([]*Player, len(bl.players))
+	for k, v := range bl.stats {
+		players[v.Turn] = bl.players[k]
+	}
+	return players
+}
+
+//Hash implements the Hasher interface.
+//It combines the hash of the board with the key of the player whose turn
+//it is, using DefaultZobrist for boards that do not implement Hasher.
+func (bl *BaseLogic) Hash() uint64 {
+	var h uint64
+	if hb, ok := bl.board.(Hasher); ok {
+		h = hb.Hash()
+	} else {
+		for y := 0; y < bl.board.Height(); y++ {
+			for x := 0; x < bl.board.Width(); x++ {
+				if s := bl.board.Get(x, y); s != "" {
+					h ^= DefaultZobrist.Key(s, x, y)
+				}
+			}
+		}
+	}
+	for k, v := range bl.stats {
+		if v.Turn == bl.turn {
+			h ^= DefaultZobrist.Key(k)
+		}
+	}
+	return h
+}
+
+//gameBoard returns the board bl is played on
+func (bl *BaseLogic) gameBoard() Board {
+	return bl.board
+}
+
+//playerStats returns the stats of the players by symbol
+func (bl *BaseLogic) playerStats() map[string]*PlayerStats {
+	return bl.stats
+}
+
 func (bl *BaseLogic) BeginTurn() {
 
 }
@@ -57,6 +132,48 @@ func (bl *BaseLogic) WhoseTurn() *Player {
 	return nil
 }
 
+//snapshot returns a function restoring the board, the turn and the stats
+//to their current state
+func (bl *BaseLogic) snapshot() func() {
+	restore, turn := snapshot2D(bl.board), bl.turn
+	moves := make(map[string]int)
+	for k, v := range bl.stats {
+		moves[k] = v.MovesMade
+	}
+	return func() {
+		restore()
+		bl.turn = turn
+		for k, v := range moves {
+			bl.stats[k].MovesMade = v
+		}
+	}
+}
+
+//apply conducts ply m, if it is legal according to g, which is the logic
+//embedding bl. Pieces are placed, removed or moved and the turn ends.
+//BaseLogic itself only accepts Place, logics that remove or move pieces
+//allow them in their CheckLegal.
+func (bl *BaseLogic) apply(g GameLogic, m Ply) error {
+	return bl.record(g, m, bl.snapshot, func() {
+		c := m.Coords
+		switch m.Action {
+		case Place:
+			bl.board.Set(c[0], c[1], m.Player.Symbol)
+		case Remove:
+			bl.board.Remove(c[0], c[1])
+		case Move:
+			bl.board.Move(c[0], c[1], c[2], c[3])
+		}
+		bl.stats[m.Player.Symbol].MovesMade++
+		bl.EndTurn()
+	})
+}
+
+//Apply implements the GameLogic interface
+func (bl *BaseLogic) Apply(m Ply) error {
+	return bl.apply(bl, m)
+}
+
 //MovesRemaining implements the GameLogic interface
 func (bl *BaseLogic) MovesRemaining() int {
 	moves := 0
@@ -70,6 +187,13 @@ func (bl *BaseLogic) MovesRemaining() int {
 	return moves
 }
 
+//LegalMoves implements the GameLogic interface.
+//Placing is the only action that takes part in an m,n,k-game, so only
+//Place plies are returned.
+func (bl *BaseLogic) LegalMoves(p *Player) []Ply {
+	return legalPlies(bl, p, bl.board, Place)
+}
+
 //IsOver implement the GameLogic interface
 func (bl *BaseLogic) IsOver() bool {
 	return bl.GetWinner() != nil || bl.MovesRemaining() == 0
@@ -77,49 +201,114 @@ func (bl *BaseLogic) IsOver() bool {
 
 //IsLegal impelments the GameLogic interface
 func (bl *BaseLogic) IsLegal(a Action, p *Player, coords ...int) bool {
-	var legal bool
+	return bl.CheckLegal(a, p, coords...) == nil
+}
 
+//CheckLegal works like IsLegal, but returns the reason why an action
+//is not legal. Fields that are not on the board result in a FieldError
+//wrapping ErrOutOfBounds instead of a panic. Pieces can only be placed.
+func (bl *BaseLogic) CheckLegal(a Action, p *Player, coords ...int) error {
+	if a != Place {
+		return fmt.Errorf("pieces can only be placed in this game")
+	}
+	return bl.checkBoard(a, p, coords...)
+}
+
+//checkBoard returns an error if action a of player p does not fit the
+//board, e.g. because a piece is placed on an occupied field. Logics
+//embedding BaseLogic that remove or move pieces check them with it.
+func (bl *BaseLogic) checkBoard(a Action, p *Player, coords ...int) error {
 	switch a {
 	case Place:
-		//		fmt.Printf("Testing if Placement is legal: Position %v Player %v\n", coords, p)
-		legal = bl.board.IsEmpty(coords[0], coords[1])
+		if len(coords) < 2 {
+			return fmt.Errorf("placing a piece takes the coordinates x,y")
+		}
+		if err := CheckBounds(bl.board, coords[0], coords[1]); err != nil {
+			return err
+		}
+		if !bl.board.IsEmpty(coords[0], coords[1]) {
+			return &FieldError{coords[0], coords[1], ErrOccupied}
+		}
 	case Remove:
-		//		fmt.Printf("Testing if Removing is legal: Position %v Player %v\n", coords, p)
+		if len(coords) < 2 {
+			return fmt.Errorf("removing a piece takes the coordinates x,y")
+		}
+		if err := CheckBounds(bl.board, coords[0], coords[1]); err != nil {
+			return err
+		}
 		//we can only remove a piece, if there is a piece and
 		//we only may remove a piece, if it is ours
-		legal = !bl.board.IsEmpty(coords[0], coords[1]) && bl.board.Get(coords[0], coords[1]) == p.Symbol
+		if bl.board.IsEmpty(coords[0], coords[1]) {
+			return &FieldError{coords[0], coords[1], ErrEmpty}
+		}
+		if bl.board.Get(coords[0], coords[1]) != p.Symbol {
+			return fmt.Errorf("the piece at (%d,%d) does not belong to %s", coords[0], coords[1], p.Name)
+		}
 	case Move:
-		//		fmt.Printf("Testing if Moving is legal: From %v To %v Player %v\n", coords[0:2], coords[2:], p)
+		if len(coords) < 4 {
+			return fmt.Errorf("moving a piece takes the coordinates x1,y1,x2,y2")
+		}
 		// see if we may take whats in place A and move it to place B
-		legal = bl.IsLegal(Remove, p, coords[0], coords[1]) && bl.IsLegal(Place, p, coords[2], coords[3])
+		if err := bl.checkBoard(Remove, p, coords[0], coords[1]); err != nil {
+			return err
+		}
+		return bl.checkBoard(Place, p, coords[2], coords[3])
 	default:
-		legal = false
+		return fmt.Errorf("unknown action %v", a)
 	}
-	return legal
+	return nil
 }
 
 //GetWinner implements the GameLogic interface
 func (bl *BaseLogic) GetWinner() *Player {
-	var winner *Player
+	return bl.winnerFor(bl.lineSymbol())
+}
+
+//lineSymbol returns the symbol that forms a complete line on the board
+//or an empty string if there is no such line
+func (bl *BaseLogic) lineSymbol() string {
+	var s string
 	//check horizontally
-	winner = bl.checkHorizontally()
+	s = bl.checkHorizontally()
 
-	if winner == nil {
+	if s == "" {
 		//fmt.Printf("Did not find a horizontal win. Checking vertically.\n")
 		//check vertically
-		winner = bl.checkVertically()
+		s = bl.checkVertically()
 	}
 
-	if winner == nil {
+	if s == "" {
 		//fmt.Printf("Did not find a horizontal win. Checking diagonally.\n")
 		//check diagnoally
-		winner = bl.checkDiagonally()
+		s = bl.checkDiagonally()
 	}
-	return winner
+	return s
 }
 
-func (bl *BaseLogic) checkHorizontally() *Player {
-	var winner *Player
+//winnerFor returns the player that wins, if a line of symbol s has been
+//completed. In misère games completing a line loses.
+func (bl *BaseLogic) winnerFor(s string) *Player {
+	if s == "" {
+		return nil
+	}
+	if bl.misere {
+		return bl.opponent(bl.players[s])
+	}
+	return bl.players[s]
+}
+
+//opponent returns the other player
+func (bl *BaseLogic) opponent(p *Player) *Player {
+	for _, o := range bl.players {
+		if o.Symbol != p.Symbol {
+			return o
+		}
+	}
+	return nil
+}
+
+func (bl *BaseLogic) checkHorizontally() string {
+	var winner string
 	var s string
 	var streaking string = ""
 	streakLen := 0
@@ -146,8 +335,8 @@ func (bl *BaseLogic) checkHorizontally() *Player {
 				streaking = ""
 				streakLen = 0
 			}
-			if streakLen == 3 {
-				return bl.players[streaking]
+			if streakLen == bl.winLength {
+				return streaking
 			}
 		} //end x
 		streaking = ""
@@ -155,12 +344,12 @@ func (bl *BaseLogic) checkHorizontally() *Player {
 	} //end y
 
 	//fmt.Printf("---- Finished Horizontal Check ----\n")
-	//return a pointer to the player that has the winning symbol
+	//return the winning symbol
 	return winner
 }
 
-func (bl *BaseLogic) checkVertically() *Player {
-	var winner *Player
+func (bl *BaseLogic) checkVertically() string {
+	var winner string
 	var s string
 	var streaking string = ""
 	streakLen := 0
@@ -189,40 +378,40 @@ func (bl *BaseLogic) checkVertically() *Player {
 				streaking = ""
 				streakLen = 0
 			}
-			if streakLen == 3 {
-				return bl.players[streaking]
+			if streakLen == bl.winLength {
+				return streaking
 			}
 		} //loop y
 		streaking = ""
 		streakLen = 0
 	} //loop x
 	//	fmt.Printf("---- Finished Vertical Check ----\n")
-	//return a pointer to the player that has the winning symbol
+	//return the winning symbol
 	return winner
 }
 
-func (bl *BaseLogic) checkDiagonally() *Player {
+func (bl *BaseLogic) checkDiagonally() string {
 	var diagonal []string
-	var p *Player
+	var s string
 	for y := 0; y < bl.board.Height(); y++ {
 		for x := 0; x < bl.board.Width(); x++ {
 			diagonal = bl.getDiagonal(x, y, LeftRight)
-			p = bl.checkSlice(diagonal)
-			if p != nil {
-				return p
+			s = bl.checkSlice(diagonal)
+			if s != "" {
+				return s
 			}
 			diagonal = bl.getDiagonal(x, y, RightLeft)
-			p = bl.checkSlice(diagonal)
-			if p != nil {
-				return p
+			s = bl.checkSlice(diagonal)
+			if s != "" {
+				return s
 			}
 		}
 	}
-	return nil
+	return ""
 }
 
-func (bl *BaseLogic) checkSlice(s []string) *Player {
-	var win *Player
+func (bl *BaseLogic) checkSlice(s []string) string {
+	var win string
 	//fmt.Printf("CheckSlice: %v\n", s)
 	var streaking string
 	streakLen := 0
@@ -233,9 +422,11 @@ func (bl *BaseLogic) checkSlice(s []string) *Player {
 		} else {
 			streakLen++
 		}
-	}
-	if streakLen == 3 {
-		win = bl.players[streaking]
+		//empty fields never form a streak
+		if streaking != "" && streakLen == bl.winLength {
+			win = streaking
+			break
+		}
 	}
 	return win
 }
@@ -1,5 +1,42 @@
 package games
 
+import (
+	"errors"
+	"fmt"
+)
+
+//Errors returned by the checked board functions
+var (
+	ErrOutOfBounds = errors.New("out of bounds")
+	ErrOccupied    = errors.New("field is occupied")
+	ErrEmpty       = errors.New("field is empty")
+)
+
+//FieldError describes why an operation on the field x,y failed.
+//Err is one of ErrOutOfBounds, ErrOccupied and ErrEmpty.
+type FieldError struct {
+	X, Y int
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field (%d,%d): %v", e.X, e.Y, e.Err)
+}
+
+//Unwrap returns the underlying error, so errors.Is can be used to test for it
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+//CheckBounds returns a FieldError wrapping ErrOutOfBounds, if x,y is not a
+//field of board b. It returns nil otherwise.
+func CheckBounds(b Board, x, y int) error {
+	if x < 0 || y < 0 || x >= b.Width() || y >= b.Height() {
+		return &FieldError{x, y, ErrOutOfBounds}
+	}
+	return nil
+}
+
 //Player has a name and Symbol
 type Player struct {
 	Name   string `json:"name"`
@@ -10,7 +47,9 @@ type Player struct {
 //A board is considered dumb. It does not implement any game logic.
 //It is merely a wrapper around the internal data structre.
 //The logic of the game should be enforced uinsg an implementation of the
-//GameLogic interface
+//GameLogic interface.
+//Set, Get, Remove and Move expect coordinates on the board. Use CheckBounds
+//or the checked functions of a board to validate user input first.
 type Board interface {
 
 	// Height returns the vertical dimension (number of fields)
@@ -37,3 +76,35 @@ type Board interface {
 	// Reset board
 	Reset()
 }
+
+//Board3D defines functions that a board with three dimensions should implement.
+//Like Board it does not implement any game logic.
+type Board3D interface {
+
+	// Height returns the vertical dimension (number of fields)
+	Height() int
+
+	// Width return the horizontal dimension (number of fields)
+	Width() int
+
+	// Depth returns the number of layers
+	Depth() int
+
+	// Set gaming piece
+	Set(x, y, z int, s string)
+
+	// Get gaming piece
+	Get(x, y, z int) string
+
+	// IsEmpty returns true, if no game piece is placed there
+	IsEmpty(x, y, z int) bool
+
+	// Remove gaming piece
+	Remove(x, y, z int)
+
+	// Move gaming piece
+	Move(x1, y1, z1, x2, y2, z2 int)
+
+	// Reset board
+	Reset()
+}
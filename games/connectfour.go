@@ -0,0 +1,83 @@
+package games
+
+import "fmt"
+
+//ConnectFourWinLength is the number of pieces in a row needed to win connect four
+const ConnectFourWinLength = 4
+
+//ConnectFourLogic implements connect four on top of BaseLogic.
+//Pieces are placed by choosing a column and fall down to the lowest
+//empty row of that column.
+type ConnectFourLogic struct {
+	*BaseLogic
+}
+
+//NewConnectFourLogic returns an initialized ConnectFourLogic.
+//The classic game is played on a board that is 7 fields wide and 6 fields high.
+func NewConnectFourLogic(b Board, players ...*Player) (*ConnectFourLogic, error) {
+	if b.Width() < ConnectFourWinLength && b.Height() < ConnectFourWinLength {
+		return nil, fmt.Errorf("the board must be at least %d fields wide or high", ConnectFourWinLength)
+	}
+	bl, err := NewMNKLogic(b, ConnectFourWinLength, players...)
+	if err != nil {
+		return nil, err
+	}
+	return &ConnectFourLogic{BaseLogic: bl}, nil
+}
+
+//LandingRow returns the row a piece dropped into column x falls to.
+//It returns -1 if x is not a column of the board or the column is full.
+func (c *ConnectFourLogic) LandingRow(x int) int {
+	if x < 0 || x >= c.board.Width() {
+		return -1
+	}
+	for y := c.board.Height() - 1; y >= 0; y-- {
+		if c.board.IsEmpty(x, y) {
+			return y
+		}
+	}
+	return -1
+}
+
+//LegalMoves implements the GameLogic interface.
+//Every ply takes the column a piece is dropped into.
+func (c *ConnectFourLogic) LegalMoves(p *Player) []Ply {
+	var plies []Ply
+	for x := 0; x < c.board.Width(); x++ {
+		if c.IsLegal(Place, p, x) {
+			plies = append(plies, Ply{Action: Place, Player: p, Coords: []int{x}})
+		}
+	}
+	return plies
+}
+
+//IsLegal implements the GameLogic interface.
+//Only placing is allowed and it takes a single coordinate: the column.
+func (c *ConnectFourLogic) IsLegal(a Action, p *Player, coords ...int) bool {
+	return c.CheckLegal(a, p, coords...) == nil
+}
+
+//CheckLegal works like IsLegal, but returns the reason why an action
+//is not legal. It returns nil if the action is legal.
+func (c *ConnectFourLogic) CheckLegal(a Action, p *Player, coords ...int) error {
+	if a != Place || len(coords) < 1 {
+		return fmt.Errorf("pieces can only be dropped into a column")
+	}
+	if coords[0] < 0 || coords[0] >= c.board.Width() {
+		return fmt.Errorf("column %d: %w", coords[0], ErrOutOfBounds)
+	}
+	if c.LandingRow(coords[0]) < 0 {
+		return fmt.Errorf("column %d is full", coords[0])
+	}
+	return nil
+}
+
+//Apply implements the GameLogic interface.
+//The piece is dropped into the column given by the only coordinate.
+func (c *ConnectFourLogic) Apply(m Ply) error {
+	return c.record(c, m, c.snapshot, func() {
+		c.board.Set(m.Coords[0], c.LandingRow(m.Coords[0]), m.Player.Symbol)
+		c.stats[m.Player.Symbol].MovesMade++
+		c.EndTurn()
+	})
+}
@@ -0,0 +1,124 @@
+package games
+
+import "testing"
+
+func TestNewConnectFourLogic(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(7, 6)
+	c, err := NewConnectFourLogic(b, &p1, &p2)
+	if err != nil || c.winLength != ConnectFourWinLength {
+		t.Errorf("NewConnectFourLogic failed. Got %v (%v)", c, err)
+	}
+
+	b1, _ := NewSimple2DBoard(3, 3)
+	c1, _ := NewConnectFourLogic(b1, &p1, &p2)
+	if c1 != nil {
+		t.Errorf("NewConnectFourLogic failed. Accepted a board that is too small")
+	}
+}
+
+func TestLandingRow(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(7, 6)
+	c, _ := NewConnectFourLogic(b, &p1, &p2)
+
+	if a := c.LandingRow(3); a != 5 {
+		t.Errorf("LandingRow failed for an empty column. Returned %d expected: %d", a, 5)
+	}
+
+	b.Set(3, 5, p1.Symbol)
+	b.Set(3, 4, p2.Symbol)
+	if a := c.LandingRow(3); a != 3 {
+		t.Errorf("LandingRow failed. Returned %d expected: %d", a, 3)
+		t.Logf("\n%v\n", b)
+	}
+
+	for y := 0; y < 4; y++ {
+		b.Set(3, y, p1.Symbol)
+	}
+	if a := c.LandingRow(3); a != -1 {
+		t.Errorf("LandingRow failed for a full column. Returned %d expected: %d", a, -1)
+		t.Logf("\n%v\n", b)
+	}
+
+	if a := c.LandingRow(7); a != -1 {
+		t.Errorf("LandingRow failed for a column outside the board. Returned %d expected: %d", a, -1)
+	}
+}
+
+func TestConnectFourIsLegal(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(7, 6)
+	c, _ := NewConnectFourLogic(b, &p1, &p2)
+
+	if !c.IsLegal(Place, &p1, 0) {
+		t.Errorf("IsLegal failed. Dropping into an empty column is not legal")
+	}
+
+	for y := 0; y < b.Height(); y++ {
+		b.Set(0, y, p2.Symbol)
+	}
+	if c.IsLegal(Place, &p1, 0) {
+		t.Errorf("IsLegal failed. Dropping into a full column is legal")
+		t.Logf("\n%v\n", b)
+	}
+
+	if c.IsLegal(Place, &p1, -1) || c.IsLegal(Place, &p1, 7) {
+		t.Errorf("IsLegal failed. Dropping outside the board is legal")
+	}
+
+	if c.IsLegal(Move, &p2, 0, 5, 1, 5) || c.IsLegal(Remove, &p2, 0, 5) {
+		t.Errorf("IsLegal failed. Only placing is legal in connect four")
+	}
+}
+
+func TestConnectFourGetWinner(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(7, 6)
+	c, _ := NewConnectFourLogic(b, &p1, &p2)
+
+	//drop o into columns 0 to 2 and x on top of them
+	for x := 0; x < 3; x++ {
+		b.Set(x, c.LandingRow(x), p1.Symbol)
+		b.Set(x, c.LandingRow(x), p2.Symbol)
+	}
+	if a := c.GetWinner(); a != nil {
+		t.Errorf("GetWinner failed. Returned %v expected: %v", a, nil)
+		t.Logf("\n%v\n", b)
+	}
+	if c.IsOver() {
+		t.Errorf("IsOver failed. The game ended without a winner")
+	}
+
+	b.Set(3, c.LandingRow(3), p1.Symbol)
+	if a := c.GetWinner(); a != &p1 {
+		t.Errorf("GetWinner failed. Returned %v expected: %v", a, &p1)
+		t.Logf("\n%v\n", b)
+	}
+	if !c.IsOver() {
+		t.Errorf("IsOver failed. The game did not end after four in a row")
+	}
+}
+
+func TestConnectFourLegalMoves(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(7, 6)
+	c, _ := NewConnectFourLogic(b, &p1, &p2)
+	for y := 0; y < 6; y++ {
+		b.Set(2, y, p1.Symbol)
+	}
+	a := c.LegalMoves(&p1)
+	if len(a) != 6 || hasPly(a, Place, 2) || !hasPly(a, Place, 6) {
+		t.Errorf("LegalMoves failed. Returned %v", a)
+	}
+}
@@ -0,0 +1,255 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+)
+
+//GomokuRule selects the rule set used by GomokuLogic
+type GomokuRule int
+
+const (
+	//Freestyle: five or more pieces in a row win
+	Freestyle GomokuRule = iota
+	//Standard: exactly five pieces in a row win, overlines do not count
+	Standard
+	//Renju: black (the first player) must not play double-threes,
+	//double-fours or overlines and wins with exactly five.
+	//White wins with five or more.
+	Renju
+)
+
+//GomokuWinLength is the number of pieces in a row needed to win gomoku
+const GomokuWinLength = 5
+
+//Errors describing placements that are forbidden for black under Renju rules
+var (
+	ErrOverline    = errors.New("overlines are forbidden for black")
+	ErrDoubleFour  = errors.New("double-fours are forbidden for black")
+	ErrDoubleThree = errors.New("double-threes are forbidden for black")
+)
+
+//lineDirections are the four directions in which a line can be formed:
+//horizontally, vertically and along both diagonals
+var lineDirections = [4][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}}
+
+//GomokuLogic implements five-in-a-row on top of BaseLogic
+type GomokuLogic struct {
+	*BaseLogic
+	rule  GomokuRule
+	black string
+}
+
+//NewGomokuLogic returns an initialized GomokuLogic using rule r.
+//The first player plays black and moves first.
+func NewGomokuLogic(b Board, r GomokuRule, players ...*Player) (*GomokuLogic, error) {
+	if r < Freestyle || r > Renju {
+		return nil, fmt.Errorf("unknown gomoku rule %d", r)
+	}
+	bl, err := NewMNKLogic(b, GomokuWinLength, players...)
+	if err != nil {
+		return nil, err
+	}
+	return &GomokuLogic{BaseLogic: bl, rule: r, black: players[0].Symbol}, nil
+}
+
+//Rule returns the rule set the game is played with
+func (g *GomokuLogic) Rule() GomokuRule {
+	return g.rule
+}
+
+//IsOver implements the GameLogic interface
+func (g *GomokuLogic) IsOver() bool {
+	return g.GetWinner() != nil || g.MovesRemaining() == 0
+}
+
+//Apply implements the GameLogic interface
+func (g *GomokuLogic) Apply(m Ply) error {
+	return g.apply(g, m)
+}
+
+//LegalMoves implements the GameLogic interface.
+//Under Renju rules placements forbidden for black are left out.
+func (g *GomokuLogic) LegalMoves(p *Player) []Ply {
+	return legalPlies(g, p, g.board, Place)
+}
+
+//IsLegal implements the GameLogic interface
+func (g *GomokuLogic) IsLegal(a Action, p *Player, coords ...int) bool {
+	return g.CheckLegal(a, p, coords...) == nil
+}
+
+//CheckLegal works like IsLegal, but returns the reason why an action
+//is not legal. It returns nil if the action is legal.
+func (g *GomokuLogic) CheckLegal(a Action, p *Player, coords ...int) error {
+	if a != Place {
+		return fmt.Errorf("pieces can only be placed in gomoku")
+	}
+	if err := g.BaseLogic.CheckLegal(a, p, coords...); err != nil {
+		return err
+	}
+	x, y := coords[0], coords[1]
+	if g.rule == Renju && p.Symbol == g.black {
+		return g.scratch().forbidden(x, y)
+	}
+	return nil
+}
+
+//GetWinner implements the GameLogic interface
+func (g *GomokuLogic) GetWinner() *Player {
+	for y := 0; y < g.board.Height(); y++ {
+		for x := 0; x < g.board.Width(); x++ {
+			if g.board.IsEmpty(x, y) {
+				continue
+			}
+			s := g.board.Get(x, y)
+			for _, d := range lineDirections {
+				//only count every line once, starting at its first piece
+				if g.isSymbol(x-d[0], y-d[1], s) {
+					continue
+				}
+				if g.wins(s, g.runLength(x, y, d[0], d[1], s)) {
+					return g.players[s]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+//wins returns true, if a line of n pieces with symbol s wins the game
+func (g *GomokuLogic) wins(s string, n int) bool {
+	switch g.rule {
+	case Standard:
+		return n == GomokuWinLength
+	case Renju:
+		return n == GomokuWinLength || (s != g.black && n > GomokuWinLength)
+	default:
+		return n >= GomokuWinLength
+	}
+}
+
+//isSymbol returns true, if x,y is on the board and holds symbol s
+func (g *GomokuLogic) isSymbol(x, y int, s string) bool {
+	if x < 0 || y < 0 || x >= g.board.Width() || y >= g.board.Height() {
+		return false
+	}
+	return g.board.Get(x, y) == s
+}
+
+//isFree returns true, if x,y is on the board and empty
+func (g *GomokuLogic) isFree(x, y int) bool {
+	return g.isSymbol(x, y, "")
+}
+
+//runLength returns the number of consecutive pieces with symbol s
+//through x,y in direction dx,dy. The piece at x,y is counted as s.
+func (g *GomokuLogic) runLength(x, y, dx, dy int, s string) int {
+	n := 1
+	for i := 1; g.isSymbol(x+i*dx, y+i*dy, s); i++ {
+		n++
+	}
+	for i := 1; g.isSymbol(x-i*dx, y-i*dy, s); i++ {
+		n++
+	}
+	return n
+}
+
+//fivePoints returns the offsets of all empty fields in direction dx,dy
+//that would complete exactly five in a row through x,y
+func (g *GomokuLogic) fivePoints(x, y, dx, dy int, s string) []int {
+	var points []int
+	for i := -GomokuWinLength + 1; i < GomokuWinLength; i++ {
+		px, py := x+i*dx, y+i*dy
+		if i == 0 || !g.isFree(px, py) {
+			continue
+		}
+		g.board.Set(px, py, s)
+		if g.runLength(x, y, dx, dy, s) == GomokuWinLength {
+			points = append(points, i)
+		}
+		g.board.Remove(px, py)
+	}
+	return points
+}
+
+//isStraightFour returns true, if the line through x,y in direction dx,dy
+//is an open four, i.e. four in a row that can be completed at both ends
+func (g *GomokuLogic) isStraightFour(x, y, dx, dy int, s string) bool {
+	p := g.fivePoints(x, y, dx, dy, s)
+	return len(p) == 2 && p[1]-p[0] == GomokuWinLength
+}
+
+//fours returns the number of fours through x,y in direction dx,dy.
+//A straight four counts once, while patterns like x.xxx.x count twice.
+func (g *GomokuLogic) fours(x, y, dx, dy int, s string) int {
+	if g.isStraightFour(x, y, dx, dy, s) {
+		return 1
+	}
+	return len(g.fivePoints(x, y, dx, dy, s))
+}
+
+//isOpenThree returns true, if the line through x,y in direction dx,dy
+//can be turned into a straight four by a placement that is not forbidden
+func (g *GomokuLogic) isOpenThree(x, y, dx, dy int, s string) bool {
+	for i := -GomokuWinLength + 1; i < GomokuWinLength; i++ {
+		px, py := x+i*dx, y+i*dy
+		if i == 0 || !g.isFree(px, py) {
+			continue
+		}
+		g.board.Set(px, py, s)
+		straight := g.isStraightFour(x, y, dx, dy, s)
+		g.board.Remove(px, py)
+		if straight && g.forbidden(px, py) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+//scratch returns a GomokuLogic with the rules of g on a copy of its board.
+//The checks of forbidden placements try pieces on it, so that they do not
+//change the board of g while others read it, e.g. parallel searches.
+func (g *GomokuLogic) scratch() *GomokuLogic {
+	b, _ := rowsBoard(boardRows(g.board))
+	return &GomokuLogic{BaseLogic: &BaseLogic{board: b, winLength: g.winLength}, rule: g.rule, black: g.black}
+}
+
+//forbidden returns the reason why black may not place a piece at the
+//empty field x,y under Renju rules, or nil if the placement is allowed.
+//It places pieces on the board temporarily, so it is called on a scratch
+//copy of the game.
+func (g *GomokuLogic) forbidden(x, y int) error {
+	g.board.Set(x, y, g.black)
+	defer g.board.Remove(x, y)
+
+	overline := false
+	for _, d := range lineDirections {
+		switch n := g.runLength(x, y, d[0], d[1], g.black); {
+		case n == GomokuWinLength:
+			//making five wins, even if other restrictions apply
+			return nil
+		case n > GomokuWinLength:
+			overline = true
+		}
+	}
+	if overline {
+		return ErrOverline
+	}
+
+	fours, threes := 0, 0
+	for _, d := range lineDirections {
+		if n := g.fours(x, y, d[0], d[1], g.black); n > 0 {
+			fours += n
+		} else if g.isOpenThree(x, y, d[0], d[1], g.black) {
+			threes++
+		}
+	}
+	if fours > 1 {
+		return ErrDoubleFour
+	}
+	if threes > 1 {
+		return ErrDoubleThree
+	}
+	return nil
+}
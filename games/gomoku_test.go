@@ -0,0 +1,150 @@
+package games
+
+import (
+	"sync"
+	"testing"
+)
+
+//setAll places the piece s on all given x,y pairs
+func setAll(b Board, s string, coords ...int) {
+	for i := 0; i+1 < len(coords); i += 2 {
+		b.Set(coords[i], coords[i+1], s)
+	}
+}
+
+func TestNewGomokuLogic(t *testing.T) {
+	b, _ := NewSimple2DBoard(15, 15)
+
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	g, err := NewGomokuLogic(b, Renju, &p1, &p2)
+	if err != nil || g.winLength != GomokuWinLength || g.black != p1.Symbol {
+		t.Errorf("NewGomokuLogic failed. Got %v (%v)", g, err)
+	}
+
+	g1, _ := NewGomokuLogic(b, GomokuRule(42), &p1, &p2)
+	if g1 != nil {
+		t.Errorf("NewGomokuLogic failed. Accepted an unknown rule")
+	}
+}
+
+func TestGomokuGetWinner(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	tests := []struct {
+		rule   GomokuRule
+		symbol string
+		length int
+		winner *Player
+	}{
+		{Freestyle, "o", 4, nil},
+		{Freestyle, "o", 5, &p1},
+		{Freestyle, "o", 6, &p1},
+		{Standard, "o", 5, &p1},
+		{Standard, "o", 6, nil},
+		{Standard, "x", 6, nil},
+		{Renju, "o", 5, &p1},
+		{Renju, "o", 6, nil},
+		{Renju, "x", 6, &p2},
+	}
+
+	for _, tc := range tests {
+		b, _ := NewSimple2DBoard(15, 15)
+		g, _ := NewGomokuLogic(b, tc.rule, &p1, &p2)
+		for i := 0; i < tc.length; i++ {
+			b.Set(2+i, 2+i, tc.symbol)
+		}
+		if a := g.GetWinner(); a != tc.winner {
+			t.Errorf("GetWinner failed for rule %d and %d*%s. Returned %v expected: %v", tc.rule, tc.length, tc.symbol, a, tc.winner)
+			t.Logf("\n%v\n", b)
+		}
+		if a := g.IsOver(); a != (tc.winner != nil) {
+			t.Errorf("IsOver failed for rule %d and %d*%s. Returned %t", tc.rule, tc.length, tc.symbol, a)
+		}
+	}
+}
+
+func TestGomokuCheckLegal(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	tests := []struct {
+		name   string
+		pieces []int
+		x, y   int
+		rule   GomokuRule
+		player *Player
+		err    error
+	}{
+		//o o . o o o
+		{"overline", []int{2, 7, 3, 7, 5, 7, 6, 7, 7, 7}, 4, 7, Renju, &p1, ErrOverline},
+		{"overline for white", []int{2, 7, 3, 7, 5, 7, 6, 7, 7, 7}, 4, 7, Renju, &p2, nil},
+		{"overline freestyle", []int{2, 7, 3, 7, 5, 7, 6, 7, 7, 7}, 4, 7, Freestyle, &p1, nil},
+		//five beats the other restrictions
+		{"five", []int{2, 7, 3, 7, 5, 7, 6, 7}, 4, 7, Renju, &p1, nil},
+		//two open threes crossing at 7,7
+		{"double three", []int{5, 7, 6, 7, 7, 5, 7, 6}, 7, 7, Renju, &p1, ErrDoubleThree},
+		{"double three for white", []int{5, 7, 6, 7, 7, 5, 7, 6}, 7, 7, Renju, &p2, nil},
+		//a three and a four are allowed
+		{"four three", []int{4, 7, 5, 7, 6, 7, 7, 5, 7, 6}, 7, 7, Renju, &p1, nil},
+		//two fours crossing at 7,7
+		{"double four", []int{4, 7, 5, 7, 6, 7, 7, 4, 7, 5, 7, 6}, 7, 7, Renju, &p1, ErrDoubleFour},
+		//o . o o o . o is a double four on a single line
+		{"double four on a line", []int{3, 7, 5, 7, 7, 7, 9, 7}, 6, 7, Renju, &p1, ErrDoubleFour},
+	}
+
+	for _, tc := range tests {
+		b, _ := NewSimple2DBoard(15, 15)
+		g, _ := NewGomokuLogic(b, tc.rule, &p1, &p2)
+		setAll(b, tc.player.Symbol, tc.pieces...)
+		if a := g.CheckLegal(Place, tc.player, tc.x, tc.y); a != tc.err {
+			t.Errorf("CheckLegal failed for %s. Returned %v expected: %v", tc.name, a, tc.err)
+			t.Logf("\n%v\n", b)
+		}
+		if a := g.IsLegal(Place, tc.player, tc.x, tc.y); a != (tc.err == nil) {
+			t.Errorf("IsLegal failed for %s. Returned %t", tc.name, a)
+		}
+	}
+
+	b, _ := NewSimple2DBoard(15, 15)
+	g, _ := NewGomokuLogic(b, Renju, &p1, &p2)
+	b.Set(0, 0, p2.Symbol)
+	if g.IsLegal(Place, &p1, 0, 0) {
+		t.Errorf("IsLegal failed. Placing on an occupied field is legal")
+	}
+	if g.IsLegal(Move, &p2, 0, 0, 1, 1) {
+		t.Errorf("IsLegal failed. Moving a piece in gomoku is legal")
+	}
+}
+
+func TestGomokuLegalMoves(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	//two open threes crossing at 7,7
+	b, _ := NewSimple2DBoard(15, 15)
+	g, _ := NewGomokuLogic(b, Renju, &p1, &p2)
+	setAll(b, p1.Symbol, 5, 7, 6, 7, 7, 5, 7, 6)
+	if a := g.LegalMoves(&p1); len(a) != 15*15-5 || hasPly(a, Place, 7, 7) {
+		t.Errorf("LegalMoves failed. Returned %d plies expected: %d", len(a), 15*15-5)
+	}
+	if a := g.LegalMoves(&p2); len(a) != 15*15-4 || !hasPly(a, Place, 7, 7) {
+		t.Errorf("LegalMoves failed. Returned %d plies expected: %d", len(a), 15*15-4)
+	}
+
+	//checking forbidden placements does not change the board, so games
+	//can be read concurrently, e.g. by parallel searches
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if a := g.LegalMoves(&p1); len(a) != 15*15-5 {
+				t.Errorf("LegalMoves failed. Returned %d plies concurrently expected: %d", len(a), 15*15-5)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,282 @@
+package games
+
+import (
+	"fmt"
+	"time"
+)
+
+//Ply is a single action of a player, e.g. placing a piece.
+//The history of a game is the ordered list of its plies.
+type Ply struct {
+	Action Action    `json:"action"`
+	Player *Player   `json:"player"`
+	Coords []int     `json:"coords"`
+	Time   time.Time `json:"time"`
+}
+
+//NewPly returns a Ply of player p conducting action a at the given
+//coordinates, taking place now
+func NewPly(a Action, p *Player, coords ...int) Ply {
+	return Ply{Action: a, Player: p, Coords: coords, Time: time.Now()}
+}
+
+func (m Ply) String() string {
+	return fmt.Sprintf("%s: %v %v", m.Player.Name, m.Action, m.Coords)
+}
+
+//legalityChecker is implemented by game logics that can explain
+//why an action is not legal
+type legalityChecker interface {
+	CheckLegal(a Action, p *Player, coords ...int) error
+}
+
+//history records the plies applied to a game together with a function
+//restoring the state before each of them. It implements Undo, Redo and
+//History of the GameLogic interface for the logic embedding it.
+type history struct {
+	plies    []Ply
+	restores []func()
+	undone   []Ply
+	//logic is the logic that applied the plies, which is used to redo them
+	logic GameLogic
+	//start holds the symbols of the 2D board before the first ply row by
+	//row. It is nil until the first ply or a SetUp.
+	start [][]string
+}
+
+//record conducts ply m if it is legal according to g and stamps it with
+//the current time unless it has one already. snapshot is called
+//before conducting the ply and returns a function restoring that state.
+//do conducts the ply on the board.
+func (h *history) record(g GameLogic, m Ply, snapshot func() func(), do func()) error {
+	if m.Player == nil {
+		return fmt.Errorf("a ply needs a player")
+	}
+	if p := g.WhoseTurn(); p == nil || *p != *m.Player {
+		return fmt.Errorf("it is not the turn of %s", m.Player.Name)
+	}
+	if c, ok := g.(legalityChecker); ok {
+		if err := c.CheckLegal(m.Action, m.Player, m.Coords...); err != nil {
+			return err
+		}
+	} else if !g.IsLegal(m.Action, m.Player, m.Coords...) {
+		return fmt.Errorf("%s may not %v a piece at %v", m.Player.Name, m.Action, m.Coords)
+	}
+
+	if m.Time.IsZero() {
+		m.Time = time.Now()
+	}
+	if bh, ok := g.(boardHolder); ok && h.start == nil {
+		h.start = boardRows(bh.gameBoard())
+	}
+	h.restores = append(h.restores, snapshot())
+	do()
+	h.plies = append(h.plies, m)
+	h.undone = nil
+	h.logic = g
+	return nil
+}
+
+//Undo implements the GameLogic interface
+func (h *history) Undo() error {
+	n := len(h.plies)
+	if n == 0 {
+		return fmt.Errorf("there is nothing to undo")
+	}
+	h.restores[n-1]()
+	h.undone = append(h.undone, h.plies[n-1])
+	h.plies, h.restores = h.plies[:n-1], h.restores[:n-1]
+	return nil
+}
+
+//Redo implements the GameLogic interface
+func (h *history) Redo() error {
+	n := len(h.undone)
+	if n == 0 {
+		return fmt.Errorf("there is nothing to redo")
+	}
+	m, rest := h.undone[n-1], h.undone[:n-1]
+	if err := h.logic.Apply(m); err != nil {
+		return err
+	}
+	h.undone = rest
+	return nil
+}
+
+//History implements the GameLogic interface
+func (h *history) History() []Ply {
+	return append([]Ply(nil), h.plies...)
+}
+
+//historyHolder is implemented by logics embedding a history
+type historyHolder interface {
+	gameHistory() *history
+}
+
+func (h *history) gameHistory() *history {
+	return h
+}
+
+//KeepRedo returns a function restoring the plies of g that are waiting to
+//be redone. Applying a ply discards them, so searches trying plies on g
+//call it before and the returned function once they are done.
+func KeepRedo(g GameLogic) func() {
+	hh, ok := g.(historyHolder)
+	if !ok {
+		return func() {}
+	}
+	h := hh.gameHistory()
+	undone := append([]Ply(nil), h.undone...)
+	return func() {
+		h.undone = undone
+	}
+}
+
+//StartPosition returns a copy of the 2D board of game g before its first
+//ply, including the pieces placed by SetUp. It returns nil for games that
+//are not played on a 2D board.
+func StartPosition(g GameLogic) *Simple2DBoard {
+	bh, ok := g.(boardHolder)
+	if !ok {
+		return nil
+	}
+	rows := boardRows(bh.gameBoard())
+	if hh, ok := g.(historyHolder); ok && len(g.History()) > 0 {
+		rows = hh.gameHistory().start
+	}
+	b, _ := rowsBoard(rows)
+	return b
+}
+
+//SetUp places the pieces on board p on the board of game g, which must not
+//have any plies yet, e.g. to start a game from a position. The result is
+//the start position of g returned by StartPosition. Every piece has to
+//belong to a player of g.
+func SetUp(g GameLogic, p Board) error {
+	bh, ok := g.(boardHolder)
+	hh, hok := g.(historyHolder)
+	if !ok || !hok {
+		return fmt.Errorf("only games on a 2D board can be set up")
+	}
+	if len(g.History()) > 0 {
+		return fmt.Errorf("the game has already started")
+	}
+	b := bh.gameBoard()
+	if b.Width() != p.Width() || b.Height() != p.Height() {
+		return fmt.Errorf("the position has %dx%d fields, but the board %dx%d", p.Width(), p.Height(), b.Width(), b.Height())
+	}
+	symbols := make(map[string]bool)
+	for _, pl := range g.Players() {
+		symbols[pl.Symbol] = true
+	}
+	for y := 0; y < p.Height(); y++ {
+		for x := 0; x < p.Width(); x++ {
+			if s := p.Get(x, y); s != "" && !symbols[s] {
+				return &FieldError{x, y, fmt.Errorf("no player has the symbol %s", s)}
+			}
+		}
+	}
+	for y := 0; y < p.Height(); y++ {
+		for x := 0; x < p.Width(); x++ {
+			switch s := p.Get(x, y); {
+			case s == b.Get(x, y):
+			case s == "":
+				b.Remove(x, y)
+			default:
+				b.Set(x, y, s)
+			}
+		}
+	}
+	h := hh.gameHistory()
+	h.start, h.undone = boardRows(b), nil
+	return nil
+}
+
+//boardRows returns the symbols of board b row by row
+func boardRows(b Board) [][]string {
+	rows := make([][]string, b.Height())
+	for y := range rows {
+		rows[y] = make([]string, b.Width())
+		for x := range rows[y] {
+			rows[y][x] = b.Get(x, y)
+		}
+	}
+	return rows
+}
+
+//rowsBoard returns a board holding the symbols of rows
+func rowsBoard(rows [][]string) (*Simple2DBoard, error) {
+	width := 0
+	if len(rows) > 0 {
+		width = len(rows[0])
+	}
+	b, err := NewSimple2DBoard(width, len(rows))
+	if err != nil {
+		return nil, err
+	}
+	for y, row := range rows {
+		if len(row) != width {
+			return nil, fmt.Errorf("row %d has %d fields instead of %d", y+1, len(row), width)
+		}
+		for x, s := range row {
+			if s != "" {
+				b.Set(x, y, s)
+			}
+		}
+	}
+	return b, nil
+}
+
+//Replay applies all plies in order to g, e.g. to restore a recorded
+//game on a fresh board
+func Replay(g GameLogic, plies []Ply) error {
+	for i, m := range plies {
+		if err := g.Apply(m); err != nil {
+			return fmt.Errorf("ply %d (%v): %v", i+1, m, err)
+		}
+	}
+	return nil
+}
+
+//snapshot2D returns a function restoring all fields of b to their current state
+func snapshot2D(b Board) func() {
+	fields := make([][]string, b.Height())
+	for y := range fields {
+		fields[y] = make([]string, b.Width())
+		for x := range fields[y] {
+			fields[y][x] = b.Get(x, y)
+		}
+	}
+	return func() {
+		for y := range fields {
+			for x, s := range fields[y] {
+				if b.Get(x, y) != s {
+					b.Set(x, y, s)
+				}
+			}
+		}
+	}
+}
+
+//snapshot3D returns a function restoring all fields of b to their current state
+func snapshot3D(b Board3D) func() {
+	fields := make([][][]string, b.Depth())
+	for z := range fields {
+		fields[z] = make([][]string, b.Height())
+		for y := range fields[z] {
+			fields[z][y] = make([]string, b.Width())
+			for x := range fields[z][y] {
+				fields[z][y][x] = b.Get(x, y, z)
+			}
+		}
+	}
+	return func() {
+		for z := range fields {
+			for y := range fields[z] {
+				for x, s := range fields[z][y] {
+					b.Set(x, y, z, s)
+				}
+			}
+		}
+	}
+}
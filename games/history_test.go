@@ -0,0 +1,168 @@
+package games
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyUndoRedo(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(3, 3)
+	bl, _ := NewBaseLogic(b, &p1, &p2)
+
+	if err := bl.Apply(NewPly(Place, &p2, 0, 0)); err == nil {
+		t.Errorf("Apply failed. Accepted a ply of the wrong player")
+	}
+	if err := bl.Apply(NewPly(Place, &p1, 0, 0)); err != nil {
+		t.Errorf("Apply failed. Returned %v", err)
+	}
+	if err := bl.Apply(NewPly(Place, &p2, 0, 0)); err == nil {
+		t.Errorf("Apply failed. Accepted a ply on an occupied field")
+	}
+	bl.Apply(NewPly(Place, &p2, 1, 1))
+	if err := bl.Apply(NewPly(Remove, &p1, 0, 0)); err == nil || b.IsEmpty(0, 0) {
+		t.Errorf("Apply failed. Removed a piece in an m,n,k-game")
+	}
+	if err := bl.Apply(NewPly(Move, &p1, 0, 0, 2, 2)); err == nil || b.IsEmpty(0, 0) {
+		t.Errorf("Apply failed. Moved a piece in an m,n,k-game")
+	}
+	if h := bl.History(); len(h) != 2 || h[1].Player != &p2 || !reflect.DeepEqual(h[1].Coords, []int{1, 1}) {
+		t.Errorf("History failed. Returned %v", h)
+	}
+
+	if err := bl.Undo(); err != nil || !b.IsEmpty(1, 1) || *bl.WhoseTurn() != p2 || len(bl.History()) != 1 {
+		t.Errorf("Undo failed. Returned %v", err)
+		t.Logf("\n%v\n", b)
+	}
+	if bl.stats[p2.Symbol].MovesMade != 0 {
+		t.Errorf("Undo failed. MovesMade is %d expected: %d", bl.stats[p2.Symbol].MovesMade, 0)
+	}
+	if err := bl.Redo(); err != nil || b.Get(1, 1) != p2.Symbol || len(bl.History()) != 2 {
+		t.Errorf("Redo failed. Returned %v", err)
+	}
+	if err := bl.Redo(); err == nil {
+		t.Errorf("Redo failed. Redid a ply that was never undone")
+	}
+
+	//applying a new ply discards the undone plies
+	bl.Undo()
+	bl.Apply(NewPly(Place, &p2, 2, 2))
+	if err := bl.Redo(); err == nil {
+		t.Errorf("Redo failed. Redid a ply after a new one was applied")
+	}
+
+	bl.Undo()
+	bl.Undo()
+	if err := bl.Undo(); err == nil || !reflect.DeepEqual(b, mustBoard(3, 3)) {
+		t.Errorf("Undo failed. Undid more plies than were applied")
+	}
+}
+
+func mustBoard(m, n int) *Simple2DBoard {
+	b, _ := NewSimple2DBoard(m, n)
+	return b
+}
+
+func TestReplay(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(3, 3)
+	bl, _ := NewBaseLogic(b, &p1, &p2)
+	for _, m := range []Ply{
+		NewPly(Place, &p1, 0, 0), NewPly(Place, &p2, 1, 0),
+		NewPly(Place, &p1, 1, 1), NewPly(Place, &p2, 2, 0),
+		NewPly(Place, &p1, 2, 2),
+	} {
+		if err := bl.Apply(m); err != nil {
+			t.Fatalf("Apply failed. Returned %v", err)
+		}
+	}
+
+	fresh := mustBoard(3, 3)
+	replayed, _ := NewBaseLogic(fresh, &p1, &p2)
+	if err := Replay(replayed, bl.History()); err != nil {
+		t.Errorf("Replay failed. Returned %v", err)
+	}
+	if !reflect.DeepEqual(b, fresh) || replayed.GetWinner() != &p1 {
+		t.Errorf("Replay failed. Got:\n%v\nexpected:\n%v", fresh, b)
+	}
+
+	invalid := []Ply{NewPly(Place, &p1, 0, 0), NewPly(Place, &p2, 0, 0)}
+	replayed, _ = NewBaseLogic(mustBoard(3, 3), &p1, &p2)
+	if err := Replay(replayed, invalid); err == nil {
+		t.Errorf("Replay failed. Accepted an illegal ply")
+	}
+}
+
+func TestUndoRestoresState(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(8, 8)
+	o, _ := NewOthelloLogic(b, &p1, &p2)
+	before := mustBoard(8, 8)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if !b.IsEmpty(x, y) {
+				before.Set(x, y, b.Get(x, y))
+			}
+		}
+	}
+	p := o.WhoseTurn()
+	x, y := -1, -1
+	for i := 0; i < 64 && x < 0; i++ {
+		if o.IsLegal(Place, p, i%8, i/8) {
+			x, y = i%8, i/8
+		}
+	}
+	if err := o.Apply(NewPly(Place, p, x, y)); err != nil {
+		t.Fatalf("Apply failed. Returned %v", err)
+	}
+	if err := o.Undo(); err != nil || !reflect.DeepEqual(b, before) || o.WhoseTurn() != p {
+		t.Errorf("Undo failed to revert flips. Got:\n%v\nexpected:\n%v", b, before)
+	}
+
+	nb, _ := NewNestedBoard(3)
+	u, _ := NewUltimateLogic(nb, &p1, &p2)
+	u.Apply(NewPly(Place, &p1, 4, 4))
+	u.Apply(NewPly(Place, &p2, 3, 3))
+	u.Undo()
+	if sx, sy, ok := u.NextBoard(); !ok || sx != 1 || sy != 1 {
+		t.Errorf("Undo failed to restore the next sub-board. Got %d,%d (%v)", sx, sy, ok)
+	}
+}
+
+func TestSetUp(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	g, _ := NewBaseLogic(mustBoard(3, 3), p1, p2)
+	if err := SetUp(g, mustPosition(t, "e../.../...")); err == nil {
+		t.Errorf("SetUp failed. Accepted a piece of an unknown player")
+	}
+	if err := SetUp(g, mustPosition(t, "..../..../....")); err == nil {
+		t.Errorf("SetUp failed. Accepted a position of another size")
+	}
+	if err := SetUp(g, mustPosition(t, "x.o/.o./...")); err != nil {
+		t.Fatalf("SetUp failed. Returned %v", err)
+	}
+	g.Apply(NewPly(Place, p2, 2, 2))
+	g.Apply(NewPly(Place, p1, 0, 2))
+	if p, _ := FormatPosition(StartPosition(g), ""); p != "x.o/.o./..." {
+		t.Errorf("StartPosition failed. Returned %q", p)
+	}
+	if err := SetUp(g, mustPosition(t, ".../.../...")); err == nil {
+		t.Errorf("SetUp failed. Set up a game that has already started")
+	}
+
+	//the start position of a game that was not set up is its initial board
+	o, _ := NewOthelloLogic(mustBoard(8, 8), p1, p2)
+	initial, _ := FormatPosition(StartPosition(o), "")
+	o.Apply(o.LegalMoves(o.WhoseTurn())[0])
+	if p, _ := FormatPosition(StartPosition(o), ""); p != initial || !strings.Contains(p, "xo") {
+		t.Errorf("StartPosition failed. Returned %q instead of %q", p, initial)
+	}
+}
@@ -1,5 +1,7 @@
 package games
 
+import "fmt"
+
 type Action int
 
 const (
@@ -8,6 +10,20 @@ const (
 	Move
 )
 
+//String returns the lower case name of the action
+func (a Action) String() string {
+	switch a {
+	case Place:
+		return "place"
+	case Remove:
+		return "remove"
+	case Move:
+		return "move"
+	default:
+		return fmt.Sprintf("action %d", int(a))
+	}
+}
+
 type Direction int
 
 const (
@@ -28,10 +44,17 @@ type GameLogic interface {
 	//that may make the next move
 	WhoseTurn() *Player
 
-	//MovesRemaining returns an array of all remaining positions that the given player
-	//can set a game piece on
+	//Players returns all players in the order of their turns
+	Players() []*Player
+
+	//MovesRemaining returns the number of actions left in the game,
+	//e.g. the number of empty fields
 	MovesRemaining() int
 
+	//LegalMoves returns all plies player p may conduct next, regardless
+	//of whether the game is already over. Their Time is not set.
+	LegalMoves(p *Player) []Ply
+
 	//GetWinner returns a pointer to the player that has won the game according
 	//to the internal rules
 	GetWinner() *Player
@@ -41,4 +64,60 @@ type GameLogic interface {
 
 	//NextRound notifies the game logic that a round has ended
 	EndTurn()
+
+	//Apply conducts the ply on the board, if it is legal, and appends it
+	//to the history. Either the whole ply is conducted or nothing changes.
+	Apply(m Ply) error
+
+	//Undo reverts the last ply of the history
+	Undo() error
+
+	//Redo applies the last undone ply again
+	Redo() error
+
+	//History returns all plies conducted so far in order
+	History() []Ply
+}
+
+//legalPlies returns all plies of player p with the given actions on board b
+//that are legal according to g. Place and Remove take the coordinates of a
+//field, Move takes the coordinates of two fields.
+func legalPlies(g GameLogic, p *Player, b Board, actions ...Action) []Ply {
+	var plies []Ply
+	for _, a := range actions {
+		for y1 := 0; y1 < b.Height(); y1++ {
+			for x1 := 0; x1 < b.Width(); x1++ {
+				if a != Move {
+					if g.IsLegal(a, p, x1, y1) {
+						plies = append(plies, Ply{Action: a, Player: p, Coords: []int{x1, y1}})
+					}
+					continue
+				}
+				for y2 := 0; y2 < b.Height(); y2++ {
+					for x2 := 0; x2 < b.Width(); x2++ {
+						if g.IsLegal(Move, p, x1, y1, x2, y2) {
+							plies = append(plies, Ply{Action: Move, Player: p, Coords: []int{x1, y1, x2, y2}})
+						}
+					}
+				}
+			}
+		}
+	}
+	return plies
+}
+
+//legalPlies3D returns all placements of player p on board b that are
+//legal according to g
+func legalPlies3D(g GameLogic, p *Player, b Board3D) []Ply {
+	var plies []Ply
+	for z := 0; z < b.Depth(); z++ {
+		for y := 0; y < b.Height(); y++ {
+			for x := 0; x < b.Width(); x++ {
+				if g.IsLegal(Place, p, x, y, z) {
+					plies = append(plies, Ply{Action: Place, Player: p, Coords: []int{x, y, z}})
+				}
+			}
+		}
+	}
+	return plies
 }
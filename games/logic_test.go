@@ -1,7 +1,7 @@
 package games
 
 import (
-	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -93,43 +93,31 @@ func TestWhoseTurn(t *testing.T) {
 		t.Logf("\n%v\n", b)
 	}
 
-	var b1 Simple2DBoard
-	json.Unmarshal([]byte(`{"Board":[
-			["o","",""],
-			["x","",""],
-			["","",""]],
-			"Width":3,
-			"Height":3}`), &b1)
+	b1 := mustPosition(t, "o../x../...")
 
-	l, _ = NewBaseLogic(&b1, &p1, &p2)
+	l, _ = NewBaseLogic(b1, &p1, &p2)
 	a = l.WhoseTurn()
 	e = &p1
 	if a != e {
 		t.Errorf("TestWhoseTurn failed. Expected %v got %v", e, a)
-		t.Logf("\n%v\n", &b1)
+		t.Logf("\n%v\n", b1)
 	}
 
-	var b2 Simple2DBoard
-	json.Unmarshal([]byte(`{"Board":[
-			["o","",""],
-			["x","",""],
-			["o","",""]],
-			"Width":3,
-			"Height":3}`), &b2)
+	b2 := mustPosition(t, "o../x../o..")
 
-	l, _ = NewBaseLogic(&b2, &p1, &p2)
+	l, _ = NewBaseLogic(b2, &p1, &p2)
 	a = l.WhoseTurn()
 	e = &p2
 	if a != e {
 		t.Errorf("TestWhoseTurn failed. Expected %v got %v", e, a)
-		t.Logf("\n%v\n", &b2)
+		t.Logf("\n%v\n", b2)
 	}
 
 }
 
 //IsOver returns true, if a winner exists or there are no moves left
 func TestIsOver(t *testing.T) {
-	var b Simple2DBoard
+	var b *Simple2DBoard
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
 	p2 := Player{Name: "Bob", Symbol: "x"}
@@ -137,22 +125,17 @@ func TestIsOver(t *testing.T) {
 	//o|x|x
 	//x|o|o
 	//o|o|x
-	json.Unmarshal([]byte(`{"Board":[
-		["o","x","x"],
-		["x","o","o"],
-		["o","o","x"]],
-		"Width":3,
-		"Height":3}`), &b)
+	b = mustPosition(t, "oxx/xoo/oox")
 
-	t.Logf("Unmarshalled board: \n%v\n (%d,%d)", &b, b.Width(), b.Height())
+	t.Logf("Board: \n%v\n (%d,%d)", b, b.Width(), b.Height())
 
-	l, _ := NewBaseLogic(&b, &p1, &p2)
+	l, _ := NewBaseLogic(b, &p1, &p2)
 
 	e := true
 	a := l.IsOver()
 	if e != a {
 		t.Errorf("IsOver failed. The game has no moves left and no winner. Expected %t Got: %t", e, a)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 }
 
@@ -184,42 +167,53 @@ func TestIsLegal(t *testing.T) {
 
 	//test, that we may not remove a piece that is not ours
 	e = false
-	a = l.IsLegal(Remove, &p2, 0, 0)
+	a = l.checkBoard(Remove, &p2, 0, 0) == nil
 	if a != e {
-		t.Errorf("IsLegal failed. Removing (%d,%d,%s) as %s returned %t, but expected %t", 0, 0, p1.Symbol, p2.Symbol, a, e)
+		t.Errorf("checkBoard failed. Removing (%d,%d,%s) as %s returned %t, but expected %t", 0, 0, p1.Symbol, p2.Symbol, a, e)
 		t.Logf("\n%v\n", &b)
 	}
 
 	//test, that we may remove a piece that is ours
 	e = true
-	a = l.IsLegal(Remove, &p1, 0, 0)
+	a = l.checkBoard(Remove, &p1, 0, 0) == nil
 	if a != e {
-		t.Errorf("IsLegal failed. Removing (%d,%d) returned %t, but expected %t", 0, 0, a, e)
+		t.Errorf("checkBoard failed. Removing (%d,%d) returned %t, but expected %t", 0, 0, a, e)
 		t.Logf("\n%v\n", &b)
 	}
 
+	//test, that fields outside of the board are not legal instead of panicking
+	if l.IsLegal(Place, &p1, 5, 5) || l.checkBoard(Move, &p1, 0, 0, 3, 0) == nil || l.checkBoard(Remove, &p1, -1, 0) == nil {
+		t.Errorf("IsLegal failed. Accepted a field outside of the board")
+	}
+	if err := l.CheckLegal(Place, &p1, 5, 5); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("CheckLegal failed. Expected ErrOutOfBounds, got %v", err)
+	}
+	if err := l.CheckLegal(Place, &p2, 0, 0); !errors.Is(err, ErrOccupied) {
+		t.Errorf("CheckLegal failed. Expected ErrOccupied, got %v", err)
+	}
+
 	//test the we may move a piece that is ours to an empty field
 	e = true
-	a = l.IsLegal(Move, &p1, 0, 0, 0, 1)
+	a = l.checkBoard(Move, &p1, 0, 0, 0, 1) == nil
 	if a != e {
-		t.Errorf("IsLegal failed. Moving (%d,%d) to (%d,%d) returned %t, but expected %t", 0, 0, 0, 1, a, e)
+		t.Errorf("checkBoard failed. Moving (%d,%d) to (%d,%d) returned %t, but expected %t", 0, 0, 0, 1, a, e)
 		t.Logf("\n%v\n", &b)
 	}
 
 	//test the we may move a piece that not ours to an empty field
 	e = false
-	a = l.IsLegal(Move, &p2, 0, 0, 0, 1)
+	a = l.checkBoard(Move, &p2, 0, 0, 0, 1) == nil
 	if a != e {
-		t.Errorf("IsLegal failed. Moving (%d,%d) to (%d,%d) returned %t, but expected %t", 0, 0, 0, 1, a, e)
+		t.Errorf("checkBoard failed. Moving (%d,%d) to (%d,%d) returned %t, but expected %t", 0, 0, 0, 1, a, e)
 		t.Logf("\n%v\n", &b)
 	}
 
 	//test that we may not move a piece that is ours to o non-empty field
 	b.Set(0, 1, p1.Symbol)
 	e = false
-	a = l.IsLegal(Move, &p1, 0, 0, 0, 1)
+	a = l.checkBoard(Move, &p1, 0, 0, 0, 1) == nil
 	if a != e {
-		t.Errorf("IsLegal failed. Moving (%d,%d) to (%d,%d) returned %t, but expected %t", 0, 0, 0, 1, a, e)
+		t.Errorf("checkBoard failed. Moving (%d,%d) to (%d,%d) returned %t, but expected %t", 0, 0, 0, 1, a, e)
 		t.Logf("\n%v\n", &b)
 	}
 
@@ -227,12 +221,19 @@ func TestIsLegal(t *testing.T) {
 	b.Remove(0, 1)
 	b.Remove(0, 0)
 	e = false
-	a = l.IsLegal(Move, &p1, 0, 0, 0, 1)
+	a = l.checkBoard(Move, &p1, 0, 0, 0, 1) == nil
 	if a != e {
-		t.Errorf("IsLegal failed. Moving (%d,%d) to (%d,%d) returned %t, but expected %t", 0, 0, 0, 1, a, e)
+		t.Errorf("checkBoard failed. Moving (%d,%d) to (%d,%d) returned %t, but expected %t", 0, 0, 0, 1, a, e)
 		t.Logf("\n%v\n", &b)
 	}
 
+	//test that BaseLogic games only place pieces
+	b.Set(0, 0, p1.Symbol)
+	if l.IsLegal(Remove, &p1, 0, 0) || l.IsLegal(Move, &p1, 0, 0, 0, 1) {
+		t.Errorf("IsLegal failed. Accepted removing or moving a piece in an m,n,k-game")
+	}
+	b.Remove(0, 0)
+
 	//test that any unknown action is not legal
 	e = false
 	a = l.IsLegal(666, &p1, 0, 0, 0, 1)
@@ -244,7 +245,7 @@ func TestIsLegal(t *testing.T) {
 }
 
 func TestGetWinnerVertically(t *testing.T) {
-	var b Simple2DBoard
+	var b *Simple2DBoard
 	var l GameLogic
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
@@ -253,44 +254,38 @@ func TestGetWinnerVertically(t *testing.T) {
 	//o|x|
 	//o|x|
 	//o| |
-	json.Unmarshal([]byte(`{"Board":[
-		["o","x",""],
-		["o","x",""],
-		["o","",""]], "Width":3, "Height":3}`), &b)
+	b = mustPosition(t, "ox./ox./o..")
 
-	t.Logf("Unmarshalled board: \n%v\n (%d,%d)", &b, b.Width(), b.Height())
+	t.Logf("Board: \n%v\n (%d,%d)", b, b.Width(), b.Height())
 
-	l, _ = NewBaseLogic(&b, &p1, &p2)
+	l, _ = NewBaseLogic(b, &p1, &p2)
 
 	e3 := &p1
 	a3 := l.GetWinner()
 	if a3 != e3 {
 		t.Errorf("TestGetWinnerVertically failed. Returned %p expected: %p", a3, e3)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 
 	//x|o|
 	//x|o|
 	// |o|
-	json.Unmarshal([]byte(`{"Board":[
-		["x","o",""],
-		["x","o",""],
-		["" ,"o",""]], "Width":3, "Height":3}`), &b)
+	b = mustPosition(t, "xo./xo./.o.")
 
-	t.Logf("Unmarshalled board: \n%v\n (%d,%d)", &b, b.Width(), b.Height())
+	t.Logf("Board: \n%v\n (%d,%d)", b, b.Width(), b.Height())
 
-	l, _ = NewBaseLogic(&b, &p1, &p2)
+	l, _ = NewBaseLogic(b, &p1, &p2)
 
 	e4 := &p1
 	a4 := l.GetWinner()
 	if a3 != e4 {
 		t.Errorf("TestGetWinnerVertically failed. Returned %p expected: %p", a4, e4)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 }
 
 func TestGetWinnerHorizontally(t *testing.T) {
-	var b Simple2DBoard
+	var b *Simple2DBoard
 	var l GameLogic
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
@@ -298,25 +293,22 @@ func TestGetWinnerHorizontally(t *testing.T) {
 	//o|o|o
 	//x| |
 	//x| |
-	json.Unmarshal([]byte(`{"Board":[
-		["o","o","o"],
-		["x","",""],
-		["x","",""]], "Width":3, "Height":3}`), &b)
+	b = mustPosition(t, "ooo/x../x..")
 
-	t.Logf("Unmarshalled board: \n%v\n (%d,%d)", &b, b.Width(), b.Height())
+	t.Logf("Board: \n%v\n (%d,%d)", b, b.Width(), b.Height())
 
-	l, _ = NewBaseLogic(&b, &p1, &p2)
+	l, _ = NewBaseLogic(b, &p1, &p2)
 
 	e2 := &p1
 	a2 := l.GetWinner()
 	if a2 != e2 {
 		t.Errorf("TestGetWinnerHorizontally failed. Returned %p expected: %p", a2, e2)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 }
 
 func TestGetWinnerDiagonalLeftRight(t *testing.T) {
-	var b Simple2DBoard
+	var b *Simple2DBoard
 	var l GameLogic
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
@@ -324,123 +316,101 @@ func TestGetWinnerDiagonalLeftRight(t *testing.T) {
 	//o|x|o
 	//x|o|x
 	//x|o|o
-	json.Unmarshal([]byte(`{"Board":[
-		["o","x","o"],
-		["x","o","x"],
-		["x","o","o"]], "Width":3, "Height":3}`), &b)
+	b = mustPosition(t, "oxo/xox/xoo")
 
-	t.Logf("Unmarshalled board: \n%v\n (%d,%d)", &b, b.Width(), b.Height())
-	l, _ = NewBaseLogic(&b, &p1, &p2)
+	t.Logf("Board: \n%v\n (%d,%d)", b, b.Width(), b.Height())
+	l, _ = NewBaseLogic(b, &p1, &p2)
 
 	a1 := l.GetWinner()
 	if a1 != &p1 {
 		t.Errorf("TestGetWinnerDiagonalLeftRight failed. Returned %v expected: %v", a1, &p1)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 }
 
 func TestGetWinnerDiagonalRightLeft(t *testing.T) {
 	var l GameLogic
-	var b Simple2DBoard
+	var b *Simple2DBoard
 	//o|x|o
 	//x|o|x
 	//o|o|x
-	json.Unmarshal([]byte(`{"Board":[
-		["o","x","o"],
-		["x","o","x"],
-		["o","o","x"]], "Width":3, "Height":3}`), &b)
+	b = mustPosition(t, "oxo/xox/oox")
 
-	t.Logf("Unmarshalled board: \n%v\n (%d,%d)", &b, b.Width(), b.Height())
+	t.Logf("Board: \n%v\n (%d,%d)", b, b.Width(), b.Height())
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
 	p2 := Player{Name: "Bob", Symbol: "x"}
 
-	l, _ = NewBaseLogic(&b, &p1, &p2)
+	l, _ = NewBaseLogic(b, &p1, &p2)
 
 	e4 := &p1
 	a4 := l.GetWinner()
 	if a4 != e4 {
 		t.Errorf("TestGetWinnerDiagonalRightLeft failed. Returned %v expected: %v", a4, e4)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 }
 
 //GetWinner returns a pointer to the player that has won the game according
 //to the internal rules
 func TestCheckHorizontally(t *testing.T) {
-	var b Simple2DBoard
-
-	json.Unmarshal([]byte(`{"Board":[
-		["o","o","o"],
-		["x","",""],
-		["x","",""]], "Width":3, "Height":3}`), &b)
+	b := mustPosition(t, "ooo/x../x..")
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
 	p2 := Player{Name: "Bob", Symbol: "x"}
 
-	l, _ := NewBaseLogic(&b, &p1, &p2)
+	l, _ := NewBaseLogic(b, &p1, &p2)
 
 	a5 := l.checkHorizontally()
-	if a5 != &p1 {
-		t.Errorf("Game ended too late. CheckHorizontally returned %p expected: %p", a5, &p1)
-		t.Logf("\n%v\n", &b)
+	if a5 != p1.Symbol {
+		t.Errorf("Game ended too late. CheckHorizontally returned %q expected: %q", a5, p1.Symbol)
+		t.Logf("\n%v\n", b)
 	}
 }
 
 func TestCheckVertically(t *testing.T) {
-	var b Simple2DBoard
-
-	json.Unmarshal([]byte(`{"Board":[
-		["o","x",""],
-		["o","x",""],
-		["o","",""]], "Width":3, "Height":3}`), &b)
+	b := mustPosition(t, "ox./ox./o..")
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
 	p2 := Player{Name: "Bob", Symbol: "x"}
 
-	l, _ := NewBaseLogic(&b, &p1, &p2)
+	l, _ := NewBaseLogic(b, &p1, &p2)
 
 	a5 := l.checkVertically()
-	if a5 != &p1 {
-		t.Errorf("Game ended too late. CheckVerticaally returned %p expected: %p", a5, &p1)
-		t.Logf("\n%v\n", &b)
+	if a5 != p1.Symbol {
+		t.Errorf("Game ended too late. CheckVerticaally returned %q expected: %q", a5, p1.Symbol)
+		t.Logf("\n%v\n", b)
 	}
 }
 
 func TestCheckDiagonally(t *testing.T) {
-	var b Simple2DBoard
+	var b *Simple2DBoard
 	//o|x|o
 	//x|o|x
 	//x|o|o
-	json.Unmarshal([]byte(`{"Board":[
-		["o","x","o"],
-		["x","o","x"],
-		["x","o","o"]], "Width":3, "Height":3}`), &b)
+	b = mustPosition(t, "oxo/xox/xoo")
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
 	p2 := Player{Name: "Bob", Symbol: "x"}
-	l, _ := NewBaseLogic(&b, &p1, &p2)
+	l, _ := NewBaseLogic(b, &p1, &p2)
 
-	e1 := &p1
+	e1 := p1.Symbol
 	a1 := l.checkDiagonally()
 	if a1 != e1 {
-		t.Errorf("Game ended too early. CheckDiagonally returned %p expected: %p", a1, e1)
-		t.Logf("\n%v\n", &b)
+		t.Errorf("Game ended too early. CheckDiagonally returned %q expected: %q", a1, e1)
+		t.Logf("\n%v\n", b)
 	}
 
 	//o|x|o
 	//x|o|x
 	//o|o|x
-	json.Unmarshal([]byte(`{"Board":[
-		["o","x","o"],
-		["x","o","x"],
-		["o","o","x"]], "Width":3, "Height":3}`), &b)
-	l.board = &b
+	b = mustPosition(t, "oxo/xox/oox")
+	l.board = b
 
-	e2 := &p1
+	e2 := p1.Symbol
 	a2 := l.checkDiagonally()
 	if a2 != e2 {
-		t.Errorf("Game ended too early. CheckDiagonally returned %p expected: %p", a2, e2)
+		t.Errorf("Game ended too early. CheckDiagonally returned %q expected: %q", a2, e2)
 		t.Logf("\n%v\n", b)
 	}
 }
@@ -449,29 +419,25 @@ func TestGetDiagonalLeftRight(t *testing.T) {
 	//o|x|o
 	//x|o|x
 	//x|o|o
-	var b Simple2DBoard
-	json.Unmarshal([]byte(`{"Board":[
-		["o","x","o"],
-		["x","o","x"],
-		["x","o","o"]], "Width":3, "Height":3}`), &b)
+	b := mustPosition(t, "oxo/xox/xoo")
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
 	p2 := Player{Name: "Bob", Symbol: "x"}
 
-	l, _ := NewBaseLogic(&b, &p1, &p2)
+	l, _ := NewBaseLogic(b, &p1, &p2)
 
 	e1 := []string{"o", "o", "o"}
 	a1 := l.getDiagonal(0, 0, LeftRight)
 	if !reflect.DeepEqual(e1, a1) {
 		t.Errorf("TestGetDiagonal returned %v expected: %v", a1, e1)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 
 	e2 := []string{"x", "o"}
 	a2 := l.getDiagonal(0, 1, LeftRight)
 	if !reflect.DeepEqual(e2, a2) {
 		t.Errorf("TestGetDiagonal returned %v expected: %v", a2, e2)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 }
 
@@ -480,36 +446,32 @@ func TestGetDiagonalRightLeft(t *testing.T) {
 	//o|x|o
 	//x|o|x
 	//o|o|x
-	var b Simple2DBoard
-	json.Unmarshal([]byte(`{"Board":[
-		["o","x","o"],
-		["x","o","x"],
-		["o","o","x"]], "Width":3, "Height":3}`), &b)
+	b := mustPosition(t, "oxo/xox/oox")
 
 	p1 := Player{Name: "Alice", Symbol: "o"}
 	p2 := Player{Name: "Bob", Symbol: "x"}
 
-	l, _ := NewBaseLogic(&b, &p1, &p2)
+	l, _ := NewBaseLogic(b, &p1, &p2)
 
 	e1 := []string{"o", "o", "o"}
 	a1 := l.getDiagonal(2, 0, RightLeft)
 	if !reflect.DeepEqual(e1, a1) {
 		t.Errorf("TestGetDiagonalRightLeft returned %v expected: %v", a1, e1)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 
 	e2 := []string{"x", "x"}
 	a2 := l.getDiagonal(1, 0, RightLeft)
 	if !reflect.DeepEqual(e2, a2) {
 		t.Errorf("TestGetDiagonalRightLeft returned %v expected: %v", a2, e2)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 
 	e3 := []string{"x", "o"}
 	a3 := l.getDiagonal(2, 1, RightLeft)
 	if !reflect.DeepEqual(e3, a3) {
 		t.Errorf("TestGetDiagonalRightLeft returned %v expected: %v", a3, e3)
-		t.Logf("\n%v\n", &b)
+		t.Logf("\n%v\n", b)
 	}
 }
 
@@ -522,7 +484,168 @@ func TestCheckSlice(t *testing.T) {
 
 	l, _ := NewBaseLogic(b, &p1, &p2)
 	a := l.checkSlice([]string{"x", "x", "x"})
+	if a != p2.Symbol {
+		t.Errorf("TestCheckSlice returned %q expected %q", a, p2.Symbol)
+	}
+}
+
+func TestNewMNKLogic(t *testing.T) {
+	b, _ := NewSimple2DBoard(15, 15)
+
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	l, err := NewMNKLogic(b, 5, &p1, &p2)
+	if err != nil || l.winLength != 5 {
+		t.Errorf("NewMNKLogic failed. Expected win length %d got %v (%v)", 5, l, err)
+	}
+
+	l0, _ := NewMNKLogic(b, 0, &p1, &p2)
+	if l0 != nil {
+		t.Errorf("NewMNKLogic failed. Accepted a win length of 0")
+	}
+
+	l3, _ := NewBaseLogic(b, &p1, &p2)
+	if l3.winLength != DefaultWinLength {
+		t.Errorf("NewBaseLogic failed. Expected win length %d got %d", DefaultWinLength, l3.winLength)
+	}
+}
+
+func TestGetWinnerFiveInARow(t *testing.T) {
+	b, _ := NewSimple2DBoard(15, 15)
+
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	l, _ := NewMNKLogic(b, 5, &p1, &p2)
+
+	for x := 3; x < 7; x++ {
+		b.Set(x, 7, p1.Symbol)
+	}
+	if a := l.GetWinner(); a != nil {
+		t.Errorf("TestGetWinnerFiveInARow failed. Four in a row returned %v expected: %v", a, nil)
+		t.Logf("\n%v\n", b)
+	}
+
+	b.Set(7, 7, p1.Symbol)
+	if a := l.GetWinner(); a != &p1 {
+		t.Errorf("TestGetWinnerFiveInARow failed. Returned %v expected: %v", a, &p1)
+		t.Logf("\n%v\n", b)
+	}
+
+	//a streak in the middle of a long diagonal
+	b, _ = NewSimple2DBoard(15, 15)
+	l, _ = NewMNKLogic(b, 5, &p1, &p2)
+	for i := 4; i < 9; i++ {
+		b.Set(i, i, p2.Symbol)
+	}
+	if a := l.GetWinner(); a != &p2 {
+		t.Errorf("TestGetWinnerFiveInARow failed. Diagonal returned %v expected: %v", a, &p2)
+		t.Logf("\n%v\n", b)
+	}
+
+	b, _ = NewSimple2DBoard(15, 15)
+	l, _ = NewMNKLogic(b, 5, &p1, &p2)
+	for i := 2; i < 7; i++ {
+		b.Set(12-i, i, p2.Symbol)
+	}
+	if a := l.GetWinner(); a != &p2 {
+		t.Errorf("TestGetWinnerFiveInARow failed. Anti-diagonal returned %v expected: %v", a, &p2)
+		t.Logf("\n%v\n", b)
+	}
+}
+
+func TestCheckSliceLongerThanWinLength(t *testing.T) {
+	var b Board
+	b, _ = NewSimple2DBoard(3, 3)
+
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	l, _ := NewBaseLogic(b, &p1, &p2)
+	a := l.checkSlice([]string{"x", "x", "x", "o", ""})
+	if a != p2.Symbol {
+		t.Errorf("TestCheckSlice returned %q expected %q", a, p2.Symbol)
+	}
+
+	a = l.checkSlice([]string{"", "", "", "o"})
+	if a != "" {
+		t.Errorf("TestCheckSlice returned %q expected no symbol", a)
+	}
+}
+
+func TestNewMisereLogic(t *testing.T) {
+	var b *Simple2DBoard
+
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	//o|o|o
+	//x|x|
+	// | |
+	b = mustPosition(t, "ooo/xx./...")
+
+	l, err := NewMisereLogic(b, DefaultWinLength, &p1, &p2)
+	if err != nil || !l.misere {
+		t.Errorf("NewMisereLogic failed. Got %v (%v)", l, err)
+	}
+
+	a := l.GetWinner()
 	if a != &p2 {
-		t.Errorf("TestCheckSlice returned %p expected %p", a, &p2)
+		t.Errorf("GetWinner failed. Completing a line in a misère game returned %v expected: %v", a, &p2)
+		t.Logf("\n%v\n", b)
+	}
+	if !l.IsOver() {
+		t.Error("IsOver failed. The misère game is not over after completing a line")
+	}
+
+	l0, _ := NewMisereLogic(b, 0, &p1, &p2)
+	if l0 != nil {
+		t.Errorf("NewMisereLogic failed. Accepted a win length of 0")
+	}
+}
+
+//mustPosition returns the board written in position notation s
+func mustPosition(t *testing.T, s string) *Simple2DBoard {
+	t.Helper()
+	b, _, err := ParsePosition(s)
+	if err != nil {
+		t.Fatalf("ParsePosition(%q) failed. Returned %v", s, err)
+	}
+	return b
+}
+
+//hasPly returns true, if plies contains action a at the given coordinates
+func hasPly(plies []Ply, a Action, coords ...int) bool {
+	for _, m := range plies {
+		if m.Action == a && reflect.DeepEqual(m.Coords, coords) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLegalMoves(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(3, 2)
+	l, _ := NewBaseLogic(b, &p1, &p2)
+	if a := l.LegalMoves(&p1); len(a) != 6 {
+		t.Errorf("LegalMoves failed. Returned %d plies expected: %d", len(a), 6)
+	}
+
+	b.Set(1, 1, p1.Symbol)
+	a := l.LegalMoves(&p2)
+	if len(a) != 5 || hasPly(a, Place, 1, 1) || !hasPly(a, Place, 2, 1) {
+		t.Errorf("LegalMoves failed. Returned %v", a)
+	}
+	for _, m := range a {
+		if m.Player != &p2 || m.Action != Place || !m.Time.IsZero() {
+			t.Errorf("LegalMoves failed. Returned %v", m)
+		}
+		if !l.IsLegal(m.Action, m.Player, m.Coords...) {
+			t.Errorf("LegalMoves failed. Returned the illegal ply %v", m)
+		}
 	}
 }
@@ -0,0 +1,135 @@
+package games
+
+import (
+	"fmt"
+	"strings"
+)
+
+// morrisMills lists the 16 mills of the nine men's morris board.
+// The 24 points lie on a 7x7 grid: three concentric squares connected
+// by four lines in the middle of their sides.
+var morrisMills = [][3][2]int{
+	//outer square
+	{{0, 0}, {3, 0}, {6, 0}}, {{6, 0}, {6, 3}, {6, 6}}, {{0, 6}, {3, 6}, {6, 6}}, {{0, 0}, {0, 3}, {0, 6}},
+	//middle square
+	{{1, 1}, {3, 1}, {5, 1}}, {{5, 1}, {5, 3}, {5, 5}}, {{1, 5}, {3, 5}, {5, 5}}, {{1, 1}, {1, 3}, {1, 5}},
+	//inner square
+	{{2, 2}, {3, 2}, {4, 2}}, {{4, 2}, {4, 3}, {4, 4}}, {{2, 4}, {3, 4}, {4, 4}}, {{2, 2}, {2, 3}, {2, 4}},
+	//lines connecting the squares
+	{{3, 0}, {3, 1}, {3, 2}}, {{6, 3}, {5, 3}, {4, 3}}, {{3, 6}, {3, 5}, {3, 4}}, {{0, 3}, {1, 3}, {2, 3}},
+}
+
+// MorrisBoard is the nine men's morris board. It is a 7x7 Simple2DBoard
+// of which only the 24 points returned by Points can hold pieces.
+type MorrisBoard struct {
+	*Simple2DBoard
+	points    map[[2]int]bool
+	neighbors map[[2]int][][2]int
+}
+
+// NewMorrisBoard initializes an empty MorrisBoard
+func NewMorrisBoard() *MorrisBoard {
+	b := &MorrisBoard{points: make(map[[2]int]bool), neighbors: make(map[[2]int][][2]int)}
+	b.Simple2DBoard, _ = NewSimple2DBoard(7, 7)
+	for _, m := range morrisMills {
+		for i, p := range m {
+			b.points[p] = true
+			if i > 0 {
+				b.neighbors[p] = append(b.neighbors[p], m[i-1])
+				b.neighbors[m[i-1]] = append(b.neighbors[m[i-1]], p)
+			}
+		}
+	}
+	return b
+}
+
+// IsPoint returns true, if a piece may be placed at x,y
+func (b *MorrisBoard) IsPoint(x, y int) bool {
+	return b.points[[2]int{x, y}]
+}
+
+// Points returns the coordinates of all 24 points row by row
+func (b *MorrisBoard) Points() [][2]int {
+	var points [][2]int
+	for y := 0; y < b.Height(); y++ {
+		for x := 0; x < b.Width(); x++ {
+			if b.IsPoint(x, y) {
+				points = append(points, [2]int{x, y})
+			}
+		}
+	}
+	return points
+}
+
+// Neighbors returns the points connected to x,y by a line
+func (b *MorrisBoard) Neighbors(x, y int) [][2]int {
+	return b.neighbors[[2]int{x, y}]
+}
+
+// IsAdjacent returns true, if x1,y1 and x2,y2 are connected by a line
+func (b *MorrisBoard) IsAdjacent(x1, y1, x2, y2 int) bool {
+	for _, n := range b.Neighbors(x1, y1) {
+		if n == [2]int{x2, y2} {
+			return true
+		}
+	}
+	return false
+}
+
+// Mills returns the mills through the point x,y
+func (b *MorrisBoard) Mills(x, y int) [][3][2]int {
+	var mills [][3][2]int
+	for _, m := range morrisMills {
+		for _, p := range m {
+			if p == [2]int{x, y} {
+				mills = append(mills, m)
+			}
+		}
+	}
+	return mills
+}
+
+// InMill returns true, if the piece at x,y is part of a mill
+func (b *MorrisBoard) InMill(x, y int) bool {
+	s := b.Get(x, y)
+	if s == "" {
+		return false
+	}
+	for _, m := range b.Mills(x, y) {
+		if b.Get(m[0][0], m[0][1]) == s && b.Get(m[1][0], m[1][1]) == s && b.Get(m[2][0], m[2][1]) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the points connected by their lines
+func (b *MorrisBoard) String() string {
+	//every column takes four characters and every row two lines
+	canvas := make([][]byte, 2*b.Height()-1)
+	for i := range canvas {
+		canvas[i] = []byte(strings.Repeat(" ", 4*b.Width()-3))
+	}
+	for p, ns := range b.neighbors {
+		for _, n := range ns {
+			for x := 4 * p[0]; x <= 4*n[0]; x++ {
+				canvas[2*p[1]][x] = '-'
+			}
+			for y := 2 * p[1]; y <= 2*n[1]; y++ {
+				canvas[y][4*p[0]] = '|'
+			}
+		}
+	}
+	for p := range b.points {
+		c := byte('.')
+		if s := b.Get(p[0], p[1]); s != "" {
+			c = s[0]
+		}
+		canvas[2*p[1]][4*p[0]] = c
+	}
+	var sb strings.Builder
+	for _, l := range canvas {
+		fmt.Fprintf(&sb, "%s\n", l)
+	}
+	return sb.String()
+}
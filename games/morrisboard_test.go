@@ -0,0 +1,69 @@
+package games
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewMorrisBoard(t *testing.T) {
+	b := NewMorrisBoard()
+	if a := len(b.Points()); a != 24 {
+		t.Errorf("NewMorrisBoard failed. Expected %d points got %d", 24, a)
+	}
+	if b.IsPoint(1, 0) || !b.IsPoint(3, 0) {
+		t.Error("IsPoint failed. Points are not placed on the concentric squares")
+	}
+}
+
+func TestMorrisBoardNeighbors(t *testing.T) {
+	b := NewMorrisBoard()
+
+	tests := []struct {
+		x, y      int
+		neighbors int
+	}{
+		{0, 0, 2},
+		{3, 0, 3},
+		{3, 1, 4},
+		{4, 4, 2},
+	}
+	for _, tc := range tests {
+		if a := len(b.Neighbors(tc.x, tc.y)); a != tc.neighbors {
+			t.Errorf("Neighbors(%d,%d) returned %d points expected %d", tc.x, tc.y, a, tc.neighbors)
+		}
+	}
+
+	if !b.IsAdjacent(3, 1, 3, 2) || !b.IsAdjacent(3, 2, 3, 1) {
+		t.Error("IsAdjacent failed. Connected points are not adjacent")
+	}
+	if b.IsAdjacent(0, 0, 1, 1) || b.IsAdjacent(3, 0, 3, 2) {
+		t.Error("IsAdjacent failed. Points that are not connected are adjacent")
+	}
+}
+
+func TestMorrisBoardInMill(t *testing.T) {
+	b := NewMorrisBoard()
+	setAll(b, "x", 3, 0, 3, 1)
+	if b.InMill(3, 0) {
+		t.Error("InMill failed. Two pieces form a mill")
+	}
+	b.Set(3, 2, "x")
+	if !b.InMill(3, 0) || !b.InMill(3, 2) {
+		t.Error("InMill failed. The mill in the middle of the top side was not found")
+	}
+	if b.InMill(0, 0) {
+		t.Error("InMill failed. An empty point is part of a mill")
+	}
+}
+
+func TestMorrisBoardString(t *testing.T) {
+	b := NewMorrisBoard()
+	b.Set(6, 0, "x")
+	lines := strings.Split(b.String(), "\n")
+	if lines[0] != ".-----------.-----------x" {
+		t.Errorf("String failed. Unexpected first line %q", lines[0])
+	}
+	if lines[6] != ".---.---.       .---.---." {
+		t.Errorf("String failed. Unexpected middle line %q", lines[6])
+	}
+}
@@ -0,0 +1,159 @@
+package games
+
+import "fmt"
+
+//MorrisLogic implements three men's morris and achi on a 3x3 board.
+//Every player first places a fixed number of pieces. Once all pieces
+//are placed, players take turns moving one of their own pieces to an
+//adjacent empty field. Three pieces in a row win.
+//
+//As the number of pieces on the board stays the same while moving,
+//the turn can not be derived from the board. Actions have to be
+//conducted using Apply, which ends the turn.
+type MorrisLogic struct {
+	*BaseLogic
+	pieces    int
+	diagonals bool
+}
+
+//NewThreeMensMorrisLogic returns a MorrisLogic for three men's morris.
+//Every player places three pieces and moves along rows and columns.
+func NewThreeMensMorrisLogic(b Board, players ...*Player) (*MorrisLogic, error) {
+	return newMorrisLogic(b, 3, false, players...)
+}
+
+//NewAchiLogic returns a MorrisLogic for achi.
+//Every player places four pieces and may also move along the two diagonals.
+func NewAchiLogic(b Board, players ...*Player) (*MorrisLogic, error) {
+	return newMorrisLogic(b, 4, true, players...)
+}
+
+func newMorrisLogic(b Board, pieces int, diagonals bool, players ...*Player) (*MorrisLogic, error) {
+	if b.Width() != 3 || b.Height() != 3 {
+		return nil, fmt.Errorf("the board must be 3x3")
+	}
+	bl, err := NewBaseLogic(b, players...)
+	if err != nil {
+		return nil, err
+	}
+	return &MorrisLogic{BaseLogic: bl, pieces: pieces, diagonals: diagonals}, nil
+}
+
+//Pieces returns the number of pieces every player places
+func (m *MorrisLogic) Pieces() int {
+	return m.pieces
+}
+
+//Placed returns the number of pieces player p has on the board
+func (m *MorrisLogic) Placed(p *Player) int {
+	n := 0
+	for y := 0; y < m.board.Height(); y++ {
+		for x := 0; x < m.board.Width(); x++ {
+			if m.board.Get(x, y) == p.Symbol {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+//NextAction returns the action player p has to conduct next:
+//Place while p has pieces left to place and Move afterwards
+func (m *MorrisLogic) NextAction(p *Player) Action {
+	if m.Placed(p) < m.pieces {
+		return Place
+	}
+	return Move
+}
+
+//IsAdjacent returns true, if a piece may move from x1,y1 to x2,y2
+//in a single step
+func (m *MorrisLogic) IsAdjacent(x1, y1, x2, y2 int) bool {
+	dx, dy := abs(x2-x1), abs(y2-y1)
+	if dx+dy == 1 {
+		return true
+	}
+	//the diagonals connect the corners with the center
+	onDiagonal := func(x, y int) bool { return x == y || x+y == 2 }
+	return m.diagonals && dx == 1 && dy == 1 && onDiagonal(x1, y1) && onDiagonal(x2, y2)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+//WhoseTurn implements the GameLogic interface
+func (m *MorrisLogic) WhoseTurn() *Player {
+	for k, v := range m.stats {
+		if v.Turn == m.turn {
+			return m.players[k]
+		}
+	}
+	return nil
+}
+
+//IsLegal implements the GameLogic interface.
+//Place takes the coordinates x,y and Move takes x1,y1,x2,y2.
+func (m *MorrisLogic) IsLegal(a Action, p *Player, coords ...int) bool {
+	return m.CheckLegal(a, p, coords...) == nil
+}
+
+//CheckLegal works like IsLegal, but returns the reason why an action
+//is not legal. It returns nil if the action is legal.
+func (m *MorrisLogic) CheckLegal(a Action, p *Player, coords ...int) error {
+	if a != Place && a != Move {
+		return fmt.Errorf("pieces can only be placed and moved")
+	}
+	if next := m.NextAction(p); a != next {
+		return fmt.Errorf("%s has to %v next", p.Name, next)
+	}
+	if err := m.checkBoard(a, p, coords...); err != nil {
+		return err
+	}
+	if a == Move && !m.IsAdjacent(coords[0], coords[1], coords[2], coords[3]) {
+		return fmt.Errorf("field (%d,%d) is not adjacent to (%d,%d)", coords[2], coords[3], coords[0], coords[1])
+	}
+	return nil
+}
+
+//Apply implements the GameLogic interface
+func (m *MorrisLogic) Apply(ply Ply) error {
+	return m.apply(m, ply)
+}
+
+//LegalMoves implements the GameLogic interface
+func (m *MorrisLogic) LegalMoves(p *Player) []Ply {
+	return legalPlies(m, p, m.board, Place, Move)
+}
+
+//MovesRemaining implements the GameLogic interface.
+//It returns the number of legal actions of the player whose turn it is.
+func (m *MorrisLogic) MovesRemaining() int {
+	return len(m.LegalMoves(m.WhoseTurn()))
+}
+
+//GetWinner implements the GameLogic interface.
+//A player that can not move any piece loses.
+func (m *MorrisLogic) GetWinner() *Player {
+	if w := m.BaseLogic.GetWinner(); w != nil {
+		return w
+	}
+	if m.MovesRemaining() > 0 {
+		return nil
+	}
+	blocked := m.WhoseTurn()
+	for _, p := range m.players {
+		if p != blocked {
+			return p
+		}
+	}
+	return nil
+}
+
+//IsOver implements the GameLogic interface
+func (m *MorrisLogic) IsOver() bool {
+	return m.GetWinner() != nil
+}
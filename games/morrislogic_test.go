@@ -0,0 +1,189 @@
+package games
+
+import "testing"
+
+func TestNewMorrisLogic(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(3, 3)
+	m, err := NewThreeMensMorrisLogic(b, &p1, &p2)
+	if err != nil || m.Pieces() != 3 || m.diagonals {
+		t.Errorf("NewThreeMensMorrisLogic failed. Got %v (%v)", m, err)
+	}
+
+	a, err := NewAchiLogic(b, &p1, &p2)
+	if err != nil || a.Pieces() != 4 || !a.diagonals {
+		t.Errorf("NewAchiLogic failed. Got %v (%v)", a, err)
+	}
+
+	b1, _ := NewSimple2DBoard(4, 4)
+	m1, _ := NewThreeMensMorrisLogic(b1, &p1, &p2)
+	if m1 != nil {
+		t.Error("NewThreeMensMorrisLogic failed. Accepted a 4x4 board")
+	}
+}
+
+func TestMorrisWhoseTurn(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(3, 3)
+	m, _ := NewThreeMensMorrisLogic(b, &p1, &p2)
+
+	if a := m.WhoseTurn(); a != &p1 {
+		t.Errorf("WhoseTurn failed. Expected %v got %v", &p1, a)
+	}
+	m.EndTurn()
+	if a := m.WhoseTurn(); a != &p2 {
+		t.Errorf("WhoseTurn failed. Expected %v got %v", &p2, a)
+	}
+	m.EndTurn()
+	if a := m.WhoseTurn(); a != &p1 {
+		t.Errorf("WhoseTurn failed. Expected %v got %v", &p1, a)
+	}
+}
+
+func TestMorrisIsLegal(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	//o|x|o
+	//x| |
+	//o|x|
+	b, _ := NewSimple2DBoard(3, 3)
+	m, _ := NewThreeMensMorrisLogic(b, &p1, &p2)
+	setAll(b, p1.Symbol, 0, 0, 2, 0)
+	setAll(b, p2.Symbol, 1, 0, 0, 1)
+
+	if m.NextAction(&p1) != Place || !m.IsLegal(Place, &p1, 1, 1) {
+		t.Error("IsLegal failed. Placing the third piece is not legal")
+	}
+	if m.IsLegal(Move, &p1, 2, 0, 2, 1) {
+		t.Error("IsLegal failed. Moving before all pieces are placed is legal")
+	}
+
+	setAll(b, p1.Symbol, 0, 2)
+	setAll(b, p2.Symbol, 1, 2)
+	if m.NextAction(&p1) != Move || m.IsLegal(Place, &p1, 1, 1) {
+		t.Error("IsLegal failed. Placing a fourth piece is legal")
+	}
+	if !m.IsLegal(Move, &p1, 2, 0, 2, 1) {
+		t.Error("IsLegal failed. Moving to an adjacent empty field is not legal")
+	}
+	if m.IsLegal(Move, &p1, 2, 0, 2, 2) {
+		t.Error("IsLegal failed. Moving two fields at once is legal")
+	}
+	if m.IsLegal(Move, &p1, 0, 2, 1, 1) {
+		t.Error("IsLegal failed. Moving diagonally in three men's morris is legal")
+	}
+	if m.IsLegal(Move, &p1, 1, 0, 1, 1) {
+		t.Error("IsLegal failed. Moving a piece of the opponent is legal")
+	}
+	if m.IsLegal(Move, &p1, 2, 0, 3, 0) {
+		t.Error("IsLegal failed. Moving off the board is legal")
+	}
+	if m.IsLegal(Remove, &p1, 2, 0) {
+		t.Error("IsLegal failed. Removing a piece is legal")
+	}
+}
+
+func TestAchiIsAdjacent(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(3, 3)
+	m, _ := NewAchiLogic(b, &p1, &p2)
+
+	tests := []struct {
+		x1, y1, x2, y2 int
+		adjacent       bool
+	}{
+		{0, 0, 1, 0, true},
+		{0, 0, 1, 1, true},
+		{2, 2, 1, 1, true},
+		{2, 0, 1, 1, true},
+		{1, 0, 0, 1, false},
+		{1, 0, 2, 1, false},
+		{0, 0, 2, 2, false},
+	}
+	for _, tc := range tests {
+		if a := m.IsAdjacent(tc.x1, tc.y1, tc.x2, tc.y2); a != tc.adjacent {
+			t.Errorf("IsAdjacent(%d,%d,%d,%d) returned %t expected %t", tc.x1, tc.y1, tc.x2, tc.y2, a, tc.adjacent)
+		}
+	}
+}
+
+func TestMorrisGetWinner(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	//o|o|
+	//x|x|o
+	//x|o|x
+	b, _ := NewSimple2DBoard(3, 3)
+	m, _ := NewAchiLogic(b, &p1, &p2)
+	setAll(b, p1.Symbol, 0, 0, 1, 0, 2, 1, 1, 2)
+	setAll(b, p2.Symbol, 0, 1, 1, 1, 0, 2, 2, 2)
+
+	if m.GetWinner() != nil || m.IsOver() {
+		t.Errorf("GetWinner failed. The game is over:\n%v", b)
+	}
+
+	//o moves up and completes the top row
+	if !m.IsLegal(Move, &p1, 2, 1, 2, 0) {
+		t.Error("IsLegal failed. Moving up is not legal")
+	}
+	b.Move(2, 1, 2, 0)
+	m.EndTurn()
+	if a := m.GetWinner(); a != &p1 || !m.IsOver() {
+		t.Errorf("GetWinner failed. Returned %v expected %v\n%v", a, &p1, b)
+	}
+
+	//o| |o
+	//x|o|x
+	//x|o|x
+	b, _ = NewSimple2DBoard(3, 3)
+	m, _ = NewAchiLogic(b, &p1, &p2)
+	setAll(b, p1.Symbol, 0, 0, 2, 0, 1, 1, 1, 2)
+	setAll(b, p2.Symbol, 0, 1, 2, 1, 0, 2, 2, 2)
+	if m.GetWinner() != nil {
+		t.Errorf("GetWinner failed. o may still move:\n%v", b)
+	}
+	//it is x's turn, but x can not move
+	m.EndTurn()
+	if a := m.MovesRemaining(); a != 0 {
+		t.Errorf("MovesRemaining failed. Expected %d got %d", 0, a)
+	}
+	if a := m.GetWinner(); a != &p1 {
+		t.Errorf("GetWinner failed. Returned %v expected %v\n%v", a, &p1, b)
+	}
+}
+
+func TestMorrisLegalMoves(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	//o|x|o
+	//x| |
+	//o| |
+	b, _ := NewSimple2DBoard(3, 3)
+	m, _ := NewThreeMensMorrisLogic(b, &p1, &p2)
+	setAll(b, p1.Symbol, 0, 0, 2, 0, 0, 2)
+	setAll(b, p2.Symbol, 1, 0, 0, 1)
+	if a := m.LegalMoves(&p2); len(a) != 4 || !hasPly(a, Place, 1, 1) || hasPly(a, Place, 0, 0) {
+		t.Errorf("LegalMoves failed while placing. Returned %v", a)
+	}
+
+	//o|x|o
+	//x| |x
+	//o|x|
+	setAll(b, p2.Symbol, 2, 1, 1, 2)
+	if a := m.LegalMoves(&p1); len(a) != 0 {
+		t.Errorf("LegalMoves failed. Returned %v for a blocked player", a)
+	}
+	a := m.LegalMoves(&p2)
+	if len(a) != 6 || !hasPly(a, Move, 2, 1, 2, 2) || !hasPly(a, Move, 0, 1, 1, 1) {
+		t.Errorf("LegalMoves failed while moving. Returned %v", a)
+	}
+}
@@ -0,0 +1,137 @@
+package games
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NestedBoard is an n x n grid of n x n Simple2DBoards, as used by
+// ultimate tic-tac-toe. It implements the Board interface using global
+// coordinates, so field x,y lies on the sub-board x/n,y/n.
+type NestedBoard struct {
+	boards [][]*Simple2DBoard
+	size   int
+	lastX  int
+	lastY  int
+}
+
+// NewNestedBoard initializes a NestedBoard with n x n sub-boards of
+// n x n fields each
+func NewNestedBoard(n int) (*NestedBoard, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("the size of a nested board must be positive")
+	}
+	b := &NestedBoard{size: n, lastX: -1, lastY: -1}
+	b.boards = make([][]*Simple2DBoard, n)
+	for i := range b.boards {
+		b.boards[i] = make([]*Simple2DBoard, n)
+		for j := range b.boards[i] {
+			b.boards[i][j], _ = NewSimple2DBoard(n, n)
+		}
+	}
+	return b, nil
+}
+
+// Size returns the number of sub-boards per row, which equals the number
+// of fields per row of every sub-board
+func (b *NestedBoard) Size() int {
+	return b.size
+}
+
+// Sub returns the sub-board at meta coordinates x,y
+func (b *NestedBoard) Sub(x, y int) *Simple2DBoard {
+	return b.boards[y][x]
+}
+
+// LastSet returns the global coordinates of the most recently placed
+// piece. ok is false if no piece has been placed since the last Reset.
+func (b *NestedBoard) LastSet() (x, y int, ok bool) {
+	return b.lastX, b.lastY, b.lastX >= 0
+}
+
+//Hash implements the Hasher interface.
+//It combines the hashes of all sub-boards and the last placed piece.
+func (b *NestedBoard) Hash() uint64 {
+	var h uint64
+	for y := range b.boards {
+		for x, sub := range b.boards[y] {
+			h ^= mix(sub.Hash() ^ DefaultZobrist.Key("sub", x, y))
+		}
+	}
+	if x, y, ok := b.LastSet(); ok {
+		h ^= DefaultZobrist.Key("last", x, y)
+	}
+	return h
+}
+
+//Width implements the Board interface
+func (b *NestedBoard) Width() int {
+	return b.size * b.size
+}
+
+//Height implements the Board interface
+func (b *NestedBoard) Height() int {
+	return b.size * b.size
+}
+
+// Set gaming piece
+func (b *NestedBoard) Set(x, y int, s string) {
+	b.boards[y/b.size][x/b.size].Set(x%b.size, y%b.size, s)
+	b.lastX, b.lastY = x, y
+}
+
+// Get gaming piece
+func (b *NestedBoard) Get(x, y int) string {
+	return b.boards[y/b.size][x/b.size].Get(x%b.size, y%b.size)
+}
+
+//IsEmpty implements the Board interface
+func (b *NestedBoard) IsEmpty(x, y int) bool {
+	return b.Get(x, y) == ""
+}
+
+// Remove gaming piece
+func (b *NestedBoard) Remove(x, y int) {
+	b.boards[y/b.size][x/b.size].Remove(x%b.size, y%b.size)
+}
+
+// Move gaming piece
+func (b *NestedBoard) Move(x1, y1, x2, y2 int) {
+	s := b.Get(x1, y1)
+	b.Remove(x1, y1)
+	b.Set(x2, y2, s)
+}
+
+// Reset board
+func (b *NestedBoard) Reset() {
+	for i := range b.boards {
+		for j := range b.boards[i] {
+			b.boards[i][j].Reset()
+		}
+	}
+	b.lastX, b.lastY = -1, -1
+}
+
+// String renders the full grid with borders around every sub-board
+func (b *NestedBoard) String() string {
+	var sb strings.Builder
+	border := strings.Repeat("+"+strings.Repeat("-", 2*b.size+1), b.size) + "+\n"
+	for y := 0; y < b.Height(); y++ {
+		if y%b.size == 0 {
+			sb.WriteString(border)
+		}
+		for x := 0; x < b.Width(); x++ {
+			if x%b.size == 0 {
+				sb.WriteString("| ")
+			}
+			if s := b.Get(x, y); s != "" {
+				sb.WriteString(s + " ")
+			} else {
+				sb.WriteString(". ")
+			}
+		}
+		sb.WriteString("|\n")
+	}
+	sb.WriteString(border)
+	return sb.String()
+}
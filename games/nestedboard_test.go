@@ -0,0 +1,72 @@
+package games
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewNestedBoard(t *testing.T) {
+	b, err := NewNestedBoard(3)
+	if err != nil || b.Width() != 9 || b.Height() != 9 {
+		t.Errorf("NewNestedBoard failed. Expected a 9x9 board got %v (%v)", b, err)
+	}
+
+	b1, _ := NewNestedBoard(0)
+	if b1 != nil {
+		t.Error("The size of a NestedBoard must be positive")
+	}
+}
+
+func TestNestedBoardSet(t *testing.T) {
+	b, _ := NewNestedBoard(3)
+	b.Set(4, 7, "x")
+	if b.Get(4, 7) != "x" {
+		t.Error("Invalid result after call to Set")
+	}
+	if b.Sub(1, 2).Get(1, 1) != "x" {
+		t.Error("Set did not place the piece on the correct sub-board")
+	}
+	if x, y, ok := b.LastSet(); !ok || x != 4 || y != 7 {
+		t.Errorf("LastSet failed. Returned (%d,%d,%t) expected (%d,%d,%t)", x, y, ok, 4, 7, true)
+	}
+}
+
+func TestNestedBoardMove(t *testing.T) {
+	b, _ := NewNestedBoard(3)
+	b.Set(0, 0, "x")
+	b.Move(0, 0, 8, 8)
+	if !b.IsEmpty(0, 0) {
+		t.Error("Move not successful: Original piece not removed.")
+	}
+	if b.Get(8, 8) != "x" {
+		t.Error("Move not successful: New piece not placed.")
+	}
+}
+
+func TestNestedBoardReset(t *testing.T) {
+	b, _ := NewNestedBoard(3)
+	b.Set(0, 0, "x")
+	b.Set(8, 8, "o")
+	b.Reset()
+	if !b.IsEmpty(0, 0) || !b.IsEmpty(8, 8) {
+		t.Error("Reset failed. Pieces are still on the board")
+	}
+	if _, _, ok := b.LastSet(); ok {
+		t.Error("Reset failed. Last placed piece was not cleared")
+	}
+}
+
+func TestNestedBoardString(t *testing.T) {
+	b, _ := NewNestedBoard(3)
+	b.Set(4, 4, "x")
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 13 {
+		t.Errorf("String failed. Expected %d lines got %d:\n%v", 13, len(lines), b)
+	}
+	if lines[0] != "+-------+-------+-------+" {
+		t.Errorf("String failed. Unexpected border %q", lines[0])
+	}
+	if lines[6] != "| . . . | . x . | . . . |" {
+		t.Errorf("String failed. Unexpected row %q", lines[6])
+	}
+}
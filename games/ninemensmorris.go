@@ -0,0 +1,234 @@
+package games
+
+import "fmt"
+
+//NineMensMorrisPieces is the number of pieces every player places
+const NineMensMorrisPieces = 9
+
+//NineMensMorrisLogic implements nine men's morris on a MorrisBoard.
+//Players first place their nine pieces and then move them along the
+//lines. Closing a mill allows removing a piece of the opponent, so a
+//turn may consist of two actions: a placement or move followed by a removal.
+//Once a player is down to three pieces, that player may fly to any empty point.
+//A player loses with less than three pieces or when unable to move.
+//
+//The logic keeps track of the turn, so actions have to be conducted using Apply.
+type NineMensMorrisLogic struct {
+	*BaseLogic
+	morris   *MorrisBoard
+	placed   map[string]int
+	removing bool
+}
+
+//NewNineMensMorrisLogic returns an initialized NineMensMorrisLogic
+func NewNineMensMorrisLogic(b *MorrisBoard, players ...*Player) (*NineMensMorrisLogic, error) {
+	bl, err := NewBaseLogic(b, players...)
+	if err != nil {
+		return nil, err
+	}
+	return &NineMensMorrisLogic{BaseLogic: bl, morris: b, placed: make(map[string]int)}, nil
+}
+
+//WhoseTurn implements the GameLogic interface
+func (n *NineMensMorrisLogic) WhoseTurn() *Player {
+	for k, v := range n.stats {
+		if v.Turn == n.turn {
+			return n.players[k]
+		}
+	}
+	return nil
+}
+
+//Pieces returns the number of pieces player p has on the board
+func (n *NineMensMorrisLogic) Pieces(p *Player) int {
+	pieces := 0
+	for _, pt := range n.morris.Points() {
+		if n.morris.Get(pt[0], pt[1]) == p.Symbol {
+			pieces++
+		}
+	}
+	return pieces
+}
+
+//Placed returns the number of pieces player p has placed so far
+func (n *NineMensMorrisLogic) Placed(p *Player) int {
+	return n.placed[p.Symbol]
+}
+
+//IsFlying returns true, if player p may move pieces to any empty point
+func (n *NineMensMorrisLogic) IsFlying(p *Player) bool {
+	return n.Placed(p) == NineMensMorrisPieces && n.Pieces(p) == 3
+}
+
+//NextAction returns the action player p has to conduct next
+func (n *NineMensMorrisLogic) NextAction(p *Player) Action {
+	switch {
+	case n.removing && p == n.WhoseTurn():
+		return Remove
+	case n.Placed(p) < NineMensMorrisPieces:
+		return Place
+	default:
+		return Move
+	}
+}
+
+//IsLegal implements the GameLogic interface.
+//Place and Remove take the coordinates x,y and Move takes x1,y1,x2,y2.
+func (n *NineMensMorrisLogic) IsLegal(a Action, p *Player, coords ...int) bool {
+	return n.CheckLegal(a, p, coords...) == nil
+}
+
+//CheckLegal works like IsLegal, but returns the reason why an action
+//is not legal. It returns nil if the action is legal.
+func (n *NineMensMorrisLogic) CheckLegal(a Action, p *Player, coords ...int) error {
+	if next := n.NextAction(p); a != next {
+		return fmt.Errorf("%s has to %v next", p.Name, next)
+	}
+	if len(coords) >= 2 {
+		if err := CheckBounds(n.morris, coords[0], coords[1]); err != nil {
+			return err
+		}
+	}
+	if len(coords) < 2 || !n.morris.IsPoint(coords[0], coords[1]) {
+		return fmt.Errorf("the coordinates are not a point on the board")
+	}
+	x, y := coords[0], coords[1]
+
+	switch a {
+	case Place:
+		if !n.morris.IsEmpty(x, y) {
+			return &FieldError{x, y, ErrOccupied}
+		}
+	case Remove:
+		o := n.opponent(p)
+		if n.morris.Get(x, y) != o.Symbol {
+			return fmt.Errorf("there is no piece of %s at (%d,%d)", o.Name, x, y)
+		}
+		if n.morris.InMill(x, y) && !n.allInMills(o) {
+			return fmt.Errorf("pieces in a mill may only be removed if all pieces are in mills")
+		}
+	case Move:
+		if len(coords) < 4 || !n.morris.IsPoint(coords[2], coords[3]) {
+			return fmt.Errorf("the destination is not a point on the board")
+		}
+		if n.morris.Get(x, y) != p.Symbol {
+			return fmt.Errorf("there is no piece of %s at (%d,%d)", p.Name, x, y)
+		}
+		if !n.morris.IsEmpty(coords[2], coords[3]) {
+			return &FieldError{coords[2], coords[3], ErrOccupied}
+		}
+		if !n.IsFlying(p) && !n.morris.IsAdjacent(x, y, coords[2], coords[3]) {
+			return fmt.Errorf("point (%d,%d) is not adjacent to (%d,%d)", coords[2], coords[3], x, y)
+		}
+	}
+	return nil
+}
+
+//allInMills returns true, if all pieces of player p are part of a mill
+func (n *NineMensMorrisLogic) allInMills(p *Player) bool {
+	for _, pt := range n.morris.Points() {
+		if n.morris.Get(pt[0], pt[1]) == p.Symbol && !n.morris.InMill(pt[0], pt[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+//Hash implements the Hasher interface.
+//Besides the board and the turn it covers the number of placed pieces
+//and whether a piece has to be removed.
+func (n *NineMensMorrisLogic) Hash() uint64 {
+	h := n.BaseLogic.Hash()
+	for s, placed := range n.placed {
+		h ^= DefaultZobrist.Key("placed "+s, placed)
+	}
+	if n.removing {
+		h ^= DefaultZobrist.Key("removing")
+	}
+	return h
+}
+
+//snapshot returns a function restoring the board, the turn and the
+//progress of both players
+func (n *NineMensMorrisLogic) snapshot() func() {
+	restore, removing := n.BaseLogic.snapshot(), n.removing
+	placed := make(map[string]int)
+	for k, v := range n.placed {
+		placed[k] = v
+	}
+	return func() {
+		restore()
+		n.placed, n.removing = placed, removing
+	}
+}
+
+//Apply implements the GameLogic interface.
+//Closing a mill keeps the turn with the player until a piece has been removed.
+func (n *NineMensMorrisLogic) Apply(m Ply) error {
+	return n.record(n, m, n.snapshot, func() {
+		p, x, y := m.Player, m.Coords[0], m.Coords[1]
+		n.stats[p.Symbol].MovesMade++
+		switch m.Action {
+		case Place:
+			n.morris.Set(x, y, p.Symbol)
+			n.placed[p.Symbol]++
+		case Move:
+			n.morris.Move(x, y, m.Coords[2], m.Coords[3])
+			x, y = m.Coords[2], m.Coords[3]
+		case Remove:
+			n.morris.Remove(x, y)
+			n.removing = false
+			n.EndTurn()
+			return
+		}
+
+		if n.morris.InMill(x, y) && n.Pieces(n.opponent(p)) > 0 {
+			n.removing = true
+			return
+		}
+		n.EndTurn()
+	})
+}
+
+//LegalMoves implements the GameLogic interface.
+//Only points of the board are considered.
+func (n *NineMensMorrisLogic) LegalMoves(p *Player) []Ply {
+	var plies []Ply
+	for _, from := range n.morris.Points() {
+		for _, a := range []Action{Place, Remove} {
+			if n.IsLegal(a, p, from[0], from[1]) {
+				plies = append(plies, Ply{Action: a, Player: p, Coords: []int{from[0], from[1]}})
+			}
+		}
+		for _, to := range n.morris.Points() {
+			if n.IsLegal(Move, p, from[0], from[1], to[0], to[1]) {
+				plies = append(plies, Ply{Action: Move, Player: p, Coords: []int{from[0], from[1], to[0], to[1]}})
+			}
+		}
+	}
+	return plies
+}
+
+//MovesRemaining implements the GameLogic interface.
+//It returns the number of legal actions of the player whose turn it is.
+func (n *NineMensMorrisLogic) MovesRemaining() int {
+	return len(n.LegalMoves(n.WhoseTurn()))
+}
+
+//GetWinner implements the GameLogic interface
+func (n *NineMensMorrisLogic) GetWinner() *Player {
+	for _, p := range n.players {
+		if n.Placed(p) == NineMensMorrisPieces && n.Pieces(p) < 3 {
+			return n.opponent(p)
+		}
+	}
+	if n.MovesRemaining() == 0 {
+		return n.opponent(n.WhoseTurn())
+	}
+	return nil
+}
+
+//IsOver implements the GameLogic interface
+func (n *NineMensMorrisLogic) IsOver() bool {
+	return n.GetWinner() != nil
+}
@@ -0,0 +1,180 @@
+package games
+
+import "testing"
+
+//playAll applies alternating placements for the current player
+func playAll(t *testing.T, n *NineMensMorrisLogic, coords ...int) {
+	for i := 0; i+1 < len(coords); i += 2 {
+		p := n.WhoseTurn()
+		if err := n.Apply(NewPly(n.NextAction(p), p, coords[i], coords[i+1])); err != nil {
+			t.Fatalf("Apply failed for (%d,%d): %v\n%v", coords[i], coords[i+1], err, n.morris)
+		}
+	}
+}
+
+func TestNineMensMorrisPlacing(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b := NewMorrisBoard()
+	n, _ := NewNineMensMorrisLogic(b, &p1, &p2)
+
+	if n.WhoseTurn() != &p1 || n.NextAction(&p1) != Place {
+		t.Error("NewNineMensMorrisLogic failed. The first player does not place first")
+	}
+	if err := n.Apply(NewPly(Place, &p1, 1, 0)); err == nil {
+		t.Error("Apply failed. Placing next to a point is legal")
+	}
+	if err := n.Apply(NewPly(Place, &p2, 0, 0)); err == nil {
+		t.Error("Apply failed. Placing out of turn is legal")
+	}
+
+	//o closes the mill on top of the outer square
+	playAll(t, n, 0, 0, 1, 1, 3, 0, 3, 1)
+	if err := n.Apply(NewPly(Place, &p1, 6, 0)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if n.WhoseTurn() != &p1 || n.NextAction(&p1) != Remove {
+		t.Errorf("Apply failed. Closing a mill does not grant a removal")
+	}
+	if n.IsLegal(Place, &p1, 6, 6) {
+		t.Error("IsLegal failed. Placing before removing is legal")
+	}
+	if n.IsLegal(Remove, &p1, 0, 0) {
+		t.Error("IsLegal failed. Removing an own piece is legal")
+	}
+	if err := n.Apply(NewPly(Remove, &p1, 1, 1)); err != nil {
+		t.Errorf("Apply failed. Removing a piece of the opponent is not legal: %v", err)
+	}
+	if !b.IsEmpty(1, 1) || n.WhoseTurn() != &p2 {
+		t.Error("Apply failed. The removal did not end the turn")
+	}
+	if a := n.Placed(&p2); a != 2 {
+		t.Errorf("Placed failed. Expected %d got %d", 2, a)
+	}
+}
+
+func TestNineMensMorrisRemoveFromMill(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b := NewMorrisBoard()
+	n, _ := NewNineMensMorrisLogic(b, &p1, &p2)
+
+	//x builds a mill on the left of the outer square and keeps
+	//another piece outside of it
+	setAll(b, p2.Symbol, 0, 0, 0, 3, 0, 6, 4, 4)
+	setAll(b, p1.Symbol, 2, 2, 3, 2)
+	n.Apply(NewPly(Place, &p1, 4, 2))
+
+	if n.IsLegal(Remove, &p1, 0, 3) {
+		t.Error("IsLegal failed. Removing from a mill is legal while other pieces are not in mills")
+	}
+	if !n.IsLegal(Remove, &p1, 4, 4) {
+		t.Error("IsLegal failed. Removing a piece outside of a mill is not legal")
+	}
+	b.Remove(4, 4)
+	if !n.IsLegal(Remove, &p1, 0, 3) {
+		t.Error("IsLegal failed. Removing from a mill is not legal although all pieces are in mills")
+	}
+}
+
+func TestNineMensMorrisMoving(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b := NewMorrisBoard()
+	n, _ := NewNineMensMorrisLogic(b, &p1, &p2)
+	n.placed[p1.Symbol] = NineMensMorrisPieces
+	n.placed[p2.Symbol] = NineMensMorrisPieces
+	setAll(b, p1.Symbol, 0, 0, 3, 1, 5, 1, 2, 4)
+	setAll(b, p2.Symbol, 6, 6, 5, 5, 4, 4, 4, 3)
+
+	if n.NextAction(&p1) != Move {
+		t.Error("NextAction failed. Moving does not follow after placing all pieces")
+	}
+	if !n.IsLegal(Move, &p1, 0, 0, 3, 0) {
+		t.Error("IsLegal failed. Moving along a line is not legal")
+	}
+	if n.IsLegal(Move, &p1, 0, 0, 1, 1) {
+		t.Error("IsLegal failed. Moving between squares at a corner is legal")
+	}
+
+	//o closes the top mill of the middle square by moving
+	if err := n.Apply(NewPly(Move, &p1, 0, 0, 0, 3)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if err := n.Apply(NewPly(Move, &p2, 4, 3, 4, 2)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if err := n.Apply(NewPly(Move, &p1, 0, 3, 1, 3)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if err := n.Apply(NewPly(Move, &p2, 4, 2, 3, 2)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if err := n.Apply(NewPly(Move, &p1, 1, 3, 1, 1)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if n.NextAction(&p1) != Remove {
+		t.Fatalf("Apply failed. Closing a mill by moving does not grant a removal\n%v", b)
+	}
+	if err := n.Apply(NewPly(Remove, &p1, 3, 2)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+
+	//x is down to three pieces and may fly
+	if !n.IsFlying(&p2) || !n.IsLegal(Move, &p2, 6, 6, 0, 0) {
+		t.Error("IsLegal failed. Flying with three pieces is not legal")
+	}
+	if n.IsFlying(&p1) {
+		t.Error("IsFlying failed. A player with four pieces may fly")
+	}
+	if n.IsOver() {
+		t.Errorf("IsOver failed. The game is over with three pieces left\n%v", b)
+	}
+
+	b.Remove(6, 6)
+	if a := n.GetWinner(); a != &p1 {
+		t.Errorf("GetWinner failed. Returned %v expected %v", a, &p1)
+	}
+}
+
+func TestNineMensMorrisBlocked(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b := NewMorrisBoard()
+	n, _ := NewNineMensMorrisLogic(b, &p1, &p2)
+	n.placed[p1.Symbol] = NineMensMorrisPieces
+	n.placed[p2.Symbol] = NineMensMorrisPieces
+
+	//o's pieces in two corners are surrounded by x
+	setAll(b, p1.Symbol, 0, 0, 6, 6, 0, 6, 6, 0)
+	setAll(b, p2.Symbol, 3, 0, 0, 3, 6, 3, 3, 6)
+
+	if a := n.MovesRemaining(); a != 0 {
+		t.Errorf("MovesRemaining failed. Expected %d got %d\n%v", 0, a, b)
+	}
+	if a := n.GetWinner(); a != &p2 {
+		t.Errorf("GetWinner failed. Returned %v expected %v", a, &p2)
+	}
+}
+
+func TestNineMensMorrisLegalMoves(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b := NewMorrisBoard()
+	n, _ := NewNineMensMorrisLogic(b, &p1, &p2)
+	if a := n.LegalMoves(&p1); len(a) != 24 {
+		t.Errorf("LegalMoves failed. Returned %d plies expected: %d", len(a), 24)
+	}
+
+	//o closes the mill on top of the outer square and has to remove a piece
+	playAll(t, n, 0, 0, 1, 1, 3, 0, 3, 1, 6, 0)
+	a := n.LegalMoves(&p1)
+	if len(a) != 2 || !hasPly(a, Remove, 1, 1) || !hasPly(a, Remove, 3, 1) {
+		t.Errorf("LegalMoves failed. Returned %v", a)
+	}
+}
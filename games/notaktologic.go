@@ -0,0 +1,143 @@
+package games
+
+import "fmt"
+
+//NotaktoSymbol is the piece both players place in notakto
+const NotaktoSymbol = "x"
+
+//NotaktoLogic implements notakto, the impartial misère variant of
+//tic-tac-toe. Both players place the same symbol on one or more 3x3
+//boards, which are the layers of a Simple3DBoard. A board on which a
+//line has been completed is dead and the player completing a line on
+//the last live board loses.
+type NotaktoLogic struct {
+	history
+	players []*Player
+	board   *Simple3DBoard
+	//lines check every layer for completed lines
+	lines []*BaseLogic
+}
+
+//NewNotaktoLogic returns an initialized NotaktoLogic.
+//Every layer of the board is a board of its own.
+func NewNotaktoLogic(b *Simple3DBoard, players ...*Player) (*NotaktoLogic, error) {
+	if b.Width() != 3 || b.Height() != 3 || b.Depth() < 1 {
+		return nil, fmt.Errorf("notakto is played on one or more 3x3 boards")
+	}
+	if players[0] == players[1] {
+		return nil, fmt.Errorf("you must supply two different players")
+	}
+	n := &NotaktoLogic{players: players, board: b}
+	for z := 0; z < b.Depth(); z++ {
+		//the line checks do not need to know about players
+		n.lines = append(n.lines, &BaseLogic{board: b.Layer(z), winLength: DefaultWinLength})
+	}
+	return n, nil
+}
+
+//IsDead returns true, if a line has been completed on board z
+func (n *NotaktoLogic) IsDead(z int) bool {
+	return n.lines[z].lineSymbol() != ""
+}
+
+//placed returns the number of pieces on all boards
+func (n *NotaktoLogic) placed() int {
+	pieces := 0
+	for z := 0; z < n.board.Depth(); z++ {
+		for y := 0; y < n.board.Height(); y++ {
+			for x := 0; x < n.board.Width(); x++ {
+				if !n.board.IsEmpty(x, y, z) {
+					pieces++
+				}
+			}
+		}
+	}
+	return pieces
+}
+
+//BeginTurn implements the GameLogic interface
+func (n *NotaktoLogic) BeginTurn() {
+
+}
+
+//EndTurn implements the GameLogic interface
+func (n *NotaktoLogic) EndTurn() {
+
+}
+
+//gameBoard3D returns the board n is played on
+func (n *NotaktoLogic) gameBoard3D() Board3D {
+	return n.board
+}
+
+//Players implements the GameLogic interface
+func (n *NotaktoLogic) Players() []*Player {
+	return append([]*Player(nil), n.players...)
+}
+
+//WhoseTurn implements the GameLogic interface
+func (n *NotaktoLogic) WhoseTurn() *Player {
+	return n.players[n.placed()%len(n.players)]
+}
+
+//LegalMoves implements the GameLogic interface.
+//Every ply places NotaktoSymbol on a live board.
+func (n *NotaktoLogic) LegalMoves(p *Player) []Ply {
+	return legalPlies3D(n, p, n.board)
+}
+
+//MovesRemaining implements the GameLogic interface.
+//Only empty fields on live boards are counted.
+func (n *NotaktoLogic) MovesRemaining() int {
+	moves := 0
+	for z := 0; z < n.board.Depth(); z++ {
+		if n.IsDead(z) {
+			continue
+		}
+		for y := 0; y < n.board.Height(); y++ {
+			for x := 0; x < n.board.Width(); x++ {
+				if n.board.IsEmpty(x, y, z) {
+					moves++
+				}
+			}
+		}
+	}
+	return moves
+}
+
+//IsLegal implements the GameLogic interface.
+//Only placing is allowed and it takes the coordinates x,y and the board z.
+func (n *NotaktoLogic) IsLegal(a Action, p *Player, coords ...int) bool {
+	if a != Place || len(coords) < 3 {
+		return false
+	}
+	x, y, z := coords[0], coords[1], coords[2]
+	if x < 0 || y < 0 || z < 0 || x >= n.board.Width() || y >= n.board.Height() || z >= n.board.Depth() {
+		return false
+	}
+	return n.board.IsEmpty(x, y, z) && !n.IsDead(z)
+}
+
+//Apply implements the GameLogic interface.
+//Both players place NotaktoSymbol.
+func (n *NotaktoLogic) Apply(m Ply) error {
+	snapshot := func() func() { return snapshot3D(n.board) }
+	return n.record(n, m, snapshot, func() {
+		n.board.Set(m.Coords[0], m.Coords[1], m.Coords[2], NotaktoSymbol)
+	})
+}
+
+//IsOver implements the GameLogic interface
+func (n *NotaktoLogic) IsOver() bool {
+	return n.MovesRemaining() == 0
+}
+
+//GetWinner implements the GameLogic interface.
+//The player that killed the last live board loses, so the winner
+//is the player that would move next.
+func (n *NotaktoLogic) GetWinner() *Player {
+	if !n.IsOver() {
+		return nil
+	}
+	return n.WhoseTurn()
+}
@@ -0,0 +1,95 @@
+package games
+
+import "testing"
+
+func TestNewNotaktoLogic(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple3DBoard(3, 3, 2)
+	n, err := NewNotaktoLogic(b, &p1, &p2)
+	if err != nil || len(n.lines) != 2 {
+		t.Errorf("NewNotaktoLogic failed. Got %v (%v)", n, err)
+	}
+
+	b1, _ := NewSimple3DBoard(4, 4, 1)
+	n1, _ := NewNotaktoLogic(b1, &p1, &p2)
+	if n1 != nil {
+		t.Error("NewNotaktoLogic failed. Accepted a 4x4 board")
+	}
+
+	n2, _ := NewNotaktoLogic(b, &p1, &p1)
+	if n2 != nil {
+		t.Error("NewNotaktoLogic failed. Accepted the same player twice")
+	}
+}
+
+func TestNotaktoIsLegal(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple3DBoard(3, 3, 2)
+	n, _ := NewNotaktoLogic(b, &p1, &p2)
+
+	if !n.IsLegal(Place, &p1, 0, 0, 1) {
+		t.Error("IsLegal failed. Placing on a live board is not legal")
+	}
+
+	for x := 0; x < 3; x++ {
+		b.Set(x, 1, 0, NotaktoSymbol)
+	}
+	if !n.IsDead(0) || n.IsDead(1) {
+		t.Errorf("IsDead failed. Expected only board 0 to be dead\n%v", b)
+	}
+	if n.IsLegal(Place, &p2, 0, 0, 0) {
+		t.Error("IsLegal failed. Placing on a dead board is legal")
+	}
+	if n.IsLegal(Place, &p2, 0, 0, 2) || n.IsLegal(Place, &p2, 0, 0) {
+		t.Error("IsLegal failed. Placing without a valid board is legal")
+	}
+	if n.IsLegal(Remove, &p2, 0, 1, 0) {
+		t.Error("IsLegal failed. Removing a piece is legal")
+	}
+}
+
+func TestNotaktoGetWinner(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple3DBoard(3, 3, 2)
+	n, _ := NewNotaktoLogic(b, &p1, &p2)
+
+	//board 0 dies after three pieces
+	setAll(b.Layer(0), NotaktoSymbol, 0, 0, 1, 1, 2, 2)
+	if n.IsOver() || n.GetWinner() != nil {
+		t.Error("IsOver failed. The game is over with a live board left")
+	}
+	if a := n.WhoseTurn(); a != &p2 {
+		t.Errorf("WhoseTurn failed. Returned %v expected %v", a, &p2)
+	}
+
+	//Bob completes a line on the last live board and loses
+	setAll(b.Layer(1), NotaktoSymbol, 0, 0, 2, 0)
+	b.Set(1, 0, 1, NotaktoSymbol)
+	if !n.IsOver() {
+		t.Errorf("IsOver failed. All boards are dead\n%v", b)
+	}
+	if a := n.GetWinner(); a != &p1 {
+		t.Errorf("GetWinner failed. Returned %v expected %v", a, &p1)
+	}
+}
+
+func TestNotaktoLegalMoves(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple3DBoard(3, 3, 2)
+	n, _ := NewNotaktoLogic(b, &p1, &p2)
+	for x := 0; x < 3; x++ {
+		b.Set(x, 0, 0, NotaktoSymbol)
+	}
+	a := n.LegalMoves(&p1)
+	if len(a) != 9 || hasPly(a, Place, 1, 1, 0) || !hasPly(a, Place, 1, 1, 1) {
+		t.Errorf("LegalMoves failed. Returned %v", a)
+	}
+}
@@ -0,0 +1,101 @@
+package games
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//A position is written in a single line like "x.o/.x./..o x": the rows of
+//the board from top to bottom separated by PositionRowSeparator, with one
+//character per field and PositionEmpty for empty fields, optionally
+//followed by a space and the symbol of the player to move.
+const (
+	PositionEmpty        = '.'
+	PositionRowSeparator = '/'
+)
+
+//FormatPosition returns the position notation of board b with player
+//toMove to move. toMove may be empty to leave out the side to move.
+//Every symbol on the board has to be a single character other than
+//PositionEmpty, PositionRowSeparator and space.
+func FormatPosition(b Board, toMove string) (string, error) {
+	var sb strings.Builder
+	for y := 0; y < b.Height(); y++ {
+		if y > 0 {
+			sb.WriteRune(PositionRowSeparator)
+		}
+		for x := 0; x < b.Width(); x++ {
+			s := b.Get(x, y)
+			if s == "" {
+				sb.WriteRune(PositionEmpty)
+				continue
+			}
+			if err := checkPositionSymbol(s); err != nil {
+				return "", &FieldError{x, y, err}
+			}
+			sb.WriteString(s)
+		}
+	}
+	if toMove != "" {
+		if err := checkPositionSymbol(toMove); err != nil {
+			return "", fmt.Errorf("side to move: %v", err)
+		}
+		sb.WriteString(" " + toMove)
+	}
+	return sb.String(), nil
+}
+
+//checkPositionSymbol returns an error, if s can not be written in the
+//position notation
+func checkPositionSymbol(s string) error {
+	r, n := utf8.DecodeRuneInString(s)
+	switch {
+	case n != len(s) || r == utf8.RuneError:
+		return fmt.Errorf("symbol %q is not a single character", s)
+	case r == PositionEmpty || r == PositionRowSeparator || r == ' ':
+		return fmt.Errorf("symbol %q is reserved by the notation", s)
+	}
+	return nil
+}
+
+//ParsePosition returns the board written in position notation s and the
+//symbol of the player to move, which is empty if s does not name it
+func ParsePosition(s string) (*Simple2DBoard, string, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, "", fmt.Errorf("a position consists of the board and optionally the side to move")
+	}
+	var toMove string
+	if len(fields) == 2 {
+		toMove = fields[1]
+		if err := checkPositionSymbol(toMove); err != nil {
+			return nil, "", fmt.Errorf("side to move: %v", err)
+		}
+	}
+
+	rows := strings.Split(fields[0], string(PositionRowSeparator))
+	width := utf8.RuneCountInString(rows[0])
+	for y, row := range rows {
+		if n := utf8.RuneCountInString(row); n != width || n == 0 {
+			return nil, "", fmt.Errorf("row %d has %d fields instead of %d", y+1, n, width)
+		}
+	}
+	b, err := NewSimple2DBoard(width, len(rows))
+	if err != nil {
+		return nil, "", err
+	}
+	for y, row := range rows {
+		x := 0
+		for _, r := range row {
+			if r == utf8.RuneError {
+				return nil, "", fmt.Errorf("row %d is not valid UTF-8", y+1)
+			}
+			if r != PositionEmpty {
+				b.Set(x, y, string(r))
+			}
+			x++
+		}
+	}
+	return b, toMove, nil
+}
@@ -0,0 +1,56 @@
+package games
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePosition(t *testing.T) {
+	b, toMove, err := ParsePosition("x.o/.x./..o x")
+	if err != nil || toMove != "x" || b.Width() != 3 || b.Height() != 3 {
+		t.Fatalf("ParsePosition failed. Returned %v, %q (%v)", b, toMove, err)
+	}
+	e, _ := NewSimple2DBoard(3, 3)
+	e.Set(0, 0, "x")
+	e.Set(2, 0, "o")
+	e.Set(1, 1, "x")
+	e.Set(2, 2, "o")
+	if b.String() != e.String() || b.Hash() != e.Hash() {
+		t.Errorf("ParsePosition failed. Got\n%v\nexpected\n%v", b, e)
+	}
+
+	if b, toMove, err := ParsePosition("...."); err != nil || toMove != "" || b.Width() != 4 || b.Height() != 1 {
+		t.Errorf("ParsePosition failed. Returned %v, %q (%v) for a position without side to move", b, toMove, err)
+	}
+
+	for _, s := range []string{"", "xo/x", "x./.. x o", "x./.. xo", "x./.. .", "x//.."} {
+		if _, _, err := ParsePosition(s); err == nil {
+			t.Errorf("ParsePosition failed. Accepted %q", s)
+		}
+	}
+}
+
+func TestFormatPosition(t *testing.T) {
+	b, _ := NewSimple2DBoard(4, 2)
+	b.Set(0, 0, "x")
+	b.Set(3, 1, "o")
+	if s, err := FormatPosition(b, "o"); err != nil || s != "x.../...o o" {
+		t.Errorf("FormatPosition failed. Returned %q (%v)", s, err)
+	}
+	if s, err := FormatPosition(b, ""); err != nil || s != "x.../...o" {
+		t.Errorf("FormatPosition failed. Returned %q (%v)", s, err)
+	}
+
+	//formatting and parsing again returns the same board
+	p, _, err := ParsePosition("xo.x/o..o")
+	s, _ := FormatPosition(p, "")
+	if err != nil || s != "xo.x/o..o" {
+		t.Errorf("FormatPosition failed. Returned %q for a parsed position (%v)", s, err)
+	}
+
+	b.Set(1, 1, "xx")
+	var fe *FieldError
+	if _, err := FormatPosition(b, ""); !errors.As(err, &fe) || fe.X != 1 || fe.Y != 1 {
+		t.Errorf("FormatPosition failed. Returned %v for a symbol of two characters", err)
+	}
+}
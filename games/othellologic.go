@@ -0,0 +1,191 @@
+package games
+
+import "fmt"
+
+//OthelloLogic implements othello (reversi) on top of BaseLogic.
+//A piece may only be placed where it flanks pieces of the opponent,
+//which are then flipped. A player without a legal placement passes
+//and the game ends once neither player can place a piece.
+//The player with more pieces on the board wins.
+type OthelloLogic struct {
+	*BaseLogic
+}
+
+//NewOthelloLogic returns an initialized OthelloLogic. The first player
+//plays black and moves first. If the four fields in the center of the
+//board are empty, they are set up with the starting position.
+func NewOthelloLogic(b Board, players ...*Player) (*OthelloLogic, error) {
+	if b.Width()%2 != 0 || b.Height()%2 != 0 || b.Width() < 4 || b.Height() < 4 {
+		return nil, fmt.Errorf("the board must have even dimensions of at least 4x4")
+	}
+	bl, err := NewBaseLogic(b, players...)
+	if err != nil {
+		return nil, err
+	}
+	o := &OthelloLogic{BaseLogic: bl}
+
+	cx, cy := b.Width()/2, b.Height()/2
+	if b.IsEmpty(cx-1, cy-1) && b.IsEmpty(cx, cy) && b.IsEmpty(cx-1, cy) && b.IsEmpty(cx, cy-1) {
+		b.Set(cx-1, cy-1, players[1].Symbol)
+		b.Set(cx, cy, players[1].Symbol)
+		b.Set(cx-1, cy, players[0].Symbol)
+		b.Set(cx, cy-1, players[0].Symbol)
+	}
+	return o, nil
+}
+
+//othelloDirections are the eight directions in which pieces can be flanked
+var othelloDirections = [8][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {-1, -1}, {1, -1}, {-1, 1}}
+
+//onBoard returns true, if x,y is a field of the board
+func (o *OthelloLogic) onBoard(x, y int) bool {
+	return x >= 0 && y >= 0 && x < o.board.Width() && y < o.board.Height()
+}
+
+//Flips returns the coordinates of all pieces that would be flipped
+//if player p placed a piece at x,y
+func (o *OthelloLogic) Flips(p *Player, x, y int) [][2]int {
+	var flips [][2]int
+	if !o.onBoard(x, y) || !o.board.IsEmpty(x, y) {
+		return flips
+	}
+	other := o.opponent(p).Symbol
+	for _, d := range othelloDirections {
+		var line [][2]int
+		fx, fy := x+d[0], y+d[1]
+		for o.onBoard(fx, fy) && o.board.Get(fx, fy) == other {
+			line = append(line, [2]int{fx, fy})
+			fx, fy = fx+d[0], fy+d[1]
+		}
+		//the line of opponent pieces has to be closed by an own piece
+		if len(line) > 0 && o.onBoard(fx, fy) && o.board.Get(fx, fy) == p.Symbol {
+			flips = append(flips, line...)
+		}
+	}
+	return flips
+}
+
+//canPlace returns true, if player p has at least one legal placement
+func (o *OthelloLogic) canPlace(p *Player) bool {
+	return o.placements(p) > 0
+}
+
+//placements returns the number of legal placements of player p
+func (o *OthelloLogic) placements(p *Player) int {
+	moves := 0
+	for y := 0; y < o.board.Height(); y++ {
+		for x := 0; x < o.board.Width(); x++ {
+			if len(o.Flips(p, x, y)) > 0 {
+				moves++
+			}
+		}
+	}
+	return moves
+}
+
+//WhoseTurn implements the GameLogic interface.
+//A player without a legal placement passes.
+func (o *OthelloLogic) WhoseTurn() *Player {
+	var p *Player
+	for k, v := range o.stats {
+		if v.Turn == o.turn {
+			p = o.players[k]
+		}
+	}
+	if !o.canPlace(p) && o.canPlace(o.opponent(p)) {
+		return o.opponent(p)
+	}
+	return p
+}
+
+//MovesRemaining implements the GameLogic interface.
+//It returns the number of legal placements of the player whose turn it is.
+func (o *OthelloLogic) MovesRemaining() int {
+	return o.placements(o.WhoseTurn())
+}
+
+//LegalMoves implements the GameLogic interface.
+//It is empty if player p has to pass.
+func (o *OthelloLogic) LegalMoves(p *Player) []Ply {
+	return legalPlies(o, p, o.board, Place)
+}
+
+//IsLegal implements the GameLogic interface
+func (o *OthelloLogic) IsLegal(a Action, p *Player, coords ...int) bool {
+	return o.CheckLegal(a, p, coords...) == nil
+}
+
+//CheckLegal works like IsLegal, but returns the reason why an action
+//is not legal. It returns nil if the action is legal.
+func (o *OthelloLogic) CheckLegal(a Action, p *Player, coords ...int) error {
+	if a != Place {
+		return fmt.Errorf("pieces can only be placed in othello")
+	}
+	if err := o.BaseLogic.CheckLegal(a, p, coords...); err != nil {
+		return err
+	}
+	if len(o.Flips(p, coords[0], coords[1])) == 0 {
+		return fmt.Errorf("a piece at (%d,%d) does not flip any piece", coords[0], coords[1])
+	}
+	return nil
+}
+
+//Apply implements the GameLogic interface.
+//The placed piece flips all flanked pieces of the opponent.
+func (o *OthelloLogic) Apply(m Ply) error {
+	return o.record(o, m, o.snapshot, func() {
+		p, x, y := m.Player, m.Coords[0], m.Coords[1]
+		for _, f := range o.Flips(p, x, y) {
+			o.board.Set(f[0], f[1], p.Symbol)
+		}
+		o.board.Set(x, y, p.Symbol)
+		o.stats[p.Symbol].MovesMade++
+		o.turn = o.stats[o.opponent(p).Symbol].Turn
+	})
+}
+
+//Score returns the number of pieces player p has on the board
+func (o *OthelloLogic) Score(p *Player) int {
+	score := 0
+	for y := 0; y < o.board.Height(); y++ {
+		for x := 0; x < o.board.Width(); x++ {
+			if o.board.Get(x, y) == p.Symbol {
+				score++
+			}
+		}
+	}
+	return score
+}
+
+//IsOver implements the GameLogic interface.
+//The game is over once neither player can place a piece.
+func (o *OthelloLogic) IsOver() bool {
+	for _, p := range o.players {
+		if o.canPlace(p) {
+			return false
+		}
+	}
+	return true
+}
+
+//GetWinner implements the GameLogic interface.
+//Once the game is over, the player with more pieces wins.
+func (o *OthelloLogic) GetWinner() *Player {
+	if !o.IsOver() {
+		return nil
+	}
+	var winner *Player
+	best, tie := -1, false
+	for _, p := range o.players {
+		switch s := o.Score(p); {
+		case s > best:
+			winner, best, tie = p, s, false
+		case s == best:
+			tie = true
+		}
+	}
+	if tie {
+		return nil
+	}
+	return winner
+}
@@ -0,0 +1,147 @@
+package games
+
+import (
+	"testing"
+)
+
+func TestNewOthelloLogic(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(8, 8)
+	o, err := NewOthelloLogic(b, &p1, &p2)
+	if err != nil {
+		t.Errorf("NewOthelloLogic failed. %v", err)
+	}
+	if b.Get(3, 3) != p2.Symbol || b.Get(4, 4) != p2.Symbol || b.Get(3, 4) != p1.Symbol || b.Get(4, 3) != p1.Symbol {
+		t.Errorf("NewOthelloLogic failed. The starting position is not set up\n%v", b)
+	}
+	if o.WhoseTurn() != &p1 {
+		t.Error("NewOthelloLogic failed. The first player does not move first")
+	}
+	if a := o.MovesRemaining(); a != 4 {
+		t.Errorf("MovesRemaining failed. Expected %d got %d", 4, a)
+	}
+
+	b1, _ := NewSimple2DBoard(7, 7)
+	o1, _ := NewOthelloLogic(b1, &p1, &p2)
+	if o1 != nil {
+		t.Error("NewOthelloLogic failed. Accepted a board with odd dimensions")
+	}
+}
+
+func TestOthelloIsLegal(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(8, 8)
+	o, _ := NewOthelloLogic(b, &p1, &p2)
+
+	if !o.IsLegal(Place, &p1, 3, 2) {
+		t.Error("IsLegal failed. Flanking a piece is not legal")
+	}
+	if o.IsLegal(Place, &p1, 2, 2) {
+		t.Error("IsLegal failed. Placing without flanking is legal")
+	}
+	if o.IsLegal(Place, &p1, 3, 3) {
+		t.Error("IsLegal failed. Placing on an occupied field is legal")
+	}
+	if o.IsLegal(Move, &p1, 3, 4, 3, 2) {
+		t.Error("IsLegal failed. Moving a piece is legal")
+	}
+}
+
+func TestOthelloApply(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(8, 8)
+	o, _ := NewOthelloLogic(b, &p1, &p2)
+
+	if err := o.Apply(NewPly(Place, &p2, 2, 4)); err == nil {
+		t.Error("Apply failed. Placing out of turn is legal")
+	}
+	if err := o.Apply(NewPly(Place, &p1, 3, 2)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if b.Get(3, 3) != p1.Symbol {
+		t.Errorf("Apply failed. The flanked piece was not flipped\n%v", b)
+	}
+	if o.Score(&p1) != 4 || o.Score(&p2) != 1 {
+		t.Errorf("Score failed. Expected 4:1 got %d:%d", o.Score(&p1), o.Score(&p2))
+	}
+	if o.WhoseTurn() != &p2 {
+		t.Error("Apply failed. The turn did not pass to the opponent")
+	}
+
+	//x flips in two directions at once
+	if err := o.Apply(NewPly(Place, &p2, 2, 2)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if b.Get(3, 3) != p2.Symbol {
+		t.Errorf("Apply failed. The diagonal was not flipped\n%v", b)
+	}
+}
+
+func TestOthelloPass(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	//x can not flank the piece in the corner, so x passes
+	b := mustPosition(t, "ox../.x../..../....")
+	o, _ := NewOthelloLogic(b, &p1, &p2)
+	o.EndTurn()
+
+	if a := o.WhoseTurn(); a != &p1 {
+		t.Errorf("WhoseTurn failed. x did not pass. Returned %v expected %v", a, &p1)
+	}
+	if o.IsOver() || o.GetWinner() != nil {
+		t.Error("IsOver failed. The game is over although o can still place")
+	}
+	if err := o.Apply(NewPly(Place, &p1, 2, 0)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if a := o.WhoseTurn(); a != &p1 {
+		t.Errorf("WhoseTurn failed. x did not pass again. Returned %v expected %v", a, &p1)
+	}
+
+	//nobody can place any more and o has more pieces
+	if err := o.Apply(NewPly(Place, &p1, 2, 2)); err != nil {
+		t.Errorf("Apply failed. %v", err)
+	}
+	if !o.IsOver() {
+		t.Errorf("IsOver failed. The game is not over\n%v", b)
+	}
+	if a := o.GetWinner(); a != &p1 {
+		t.Errorf("GetWinner failed. Returned %v expected %v", a, &p1)
+	}
+}
+
+func TestOthelloDraw(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b := mustPosition(t, "ooxx/ooxx/ooxx/ooxx")
+	o, _ := NewOthelloLogic(b, &p1, &p2)
+
+	if !o.IsOver() || o.GetWinner() != nil {
+		t.Errorf("GetWinner failed. Returned %v for a draw", o.GetWinner())
+	}
+}
+
+func TestOthelloLegalMoves(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(8, 8)
+	o, _ := NewOthelloLogic(b, &p1, &p2)
+	a := o.LegalMoves(o.WhoseTurn())
+	if len(a) != 4 || len(a) != o.MovesRemaining() {
+		t.Errorf("LegalMoves failed. Returned %v", a)
+	}
+	for _, m := range a {
+		if len(o.Flips(m.Player, m.Coords[0], m.Coords[1])) != 1 {
+			t.Errorf("LegalMoves failed. %v does not flip a single piece", m)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//FormatVersion is the version of the JSON documents written by this
+//package. Version 1 are the documents written before they were versioned.
+const FormatVersion = 2
+
+//Kinds of persisted documents
+const (
+	KindBoard = "board"
+	KindGame  = "game"
+)
+
+//Envelope wraps every persisted board and game with the version of its
+//format, so documents of older versions can be migrated when they are read
+type Envelope struct {
+	Version int             `json:"version"`
+	Kind    string          `json:"kind"`
+	Data    json.RawMessage `json:"data"`
+}
+
+//migration converts the data of a document of one version to the next one
+type migration func(data json.RawMessage) (json.RawMessage, error)
+
+//migrations holds the migrations of every kind by the version they
+//migrate from
+var migrations = map[string]map[int]migration{
+	KindBoard: {1: migrateBoardV1},
+	//games only gained the envelope
+	KindGame: {1: func(data json.RawMessage) (json.RawMessage, error) { return data, nil }},
+}
+
+//seal wraps v as the data of a document of the given kind in the current
+//version
+func seal(kind string, v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Envelope{Version: FormatVersion, Kind: kind, Data: data})
+}
+
+//unseal returns the data of the document of the given kind in doc migrated
+//to the current version. Documents without an envelope are of version 1.
+func unseal(kind string, doc []byte) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return nil, err
+	}
+	e := Envelope{Version: 1, Kind: kind}
+	if _, ok := fields["version"]; ok {
+		if err := json.Unmarshal(doc, &e); err != nil {
+			return nil, err
+		}
+	} else {
+		e.Data = append(json.RawMessage(nil), doc...)
+	}
+	switch {
+	case e.Kind != kind:
+		return nil, fmt.Errorf("the document is a %s instead of a %s", e.Kind, kind)
+	case e.Version < 1:
+		return nil, fmt.Errorf("invalid version %d", e.Version)
+	case e.Version > FormatVersion:
+		return nil, fmt.Errorf("version %d was written by a newer program, the latest known version is %d", e.Version, FormatVersion)
+	}
+	for v := e.Version; v < FormatVersion; v++ {
+		var err error
+		if e.Data, err = migrations[kind][v](e.Data); err != nil {
+			return nil, fmt.Errorf("migrating the %s from version %d: %v", kind, v, err)
+		}
+	}
+	return e.Data, nil
+}
+
+//jsonBoard is the data of a board document since version 2.
+//Rows holds the symbols row by row, so Rows[y][x] is the field x,y.
+type jsonBoard struct {
+	Width  int        `json:"width"`
+	Height int        `json:"height"`
+	Rows   [][]string `json:"rows"`
+}
+
+//migrateBoardV1 converts a JSONSimple2DBoard to a jsonBoard. Before
+//version 2 NewSimple2DBoard created Width rows of Height fields, while Get
+//read Board[y][x] all the same. The fields of such non-square boards are
+//copied into Height rows of Width fields.
+func migrateBoardV1(data json.RawMessage) (json.RawMessage, error) {
+	var js JSONSimple2DBoard
+	if err := json.Unmarshal(data, &js); err != nil {
+		return nil, err
+	}
+	rows := js.Board
+	if js.Width != js.Height && js.Width >= 0 && js.Height >= 0 && len(js.Board) == js.Width {
+		rows = make([][]string, js.Height)
+		for y := range rows {
+			rows[y] = make([]string, js.Width)
+		}
+		for y, row := range js.Board {
+			if len(row) != js.Height {
+				return nil, fmt.Errorf("row %d has %d fields instead of %d", y+1, len(row), js.Height)
+			}
+			for x, s := range row {
+				switch {
+				case y < js.Height && x < js.Width:
+					rows[y][x] = s
+				case s != "":
+					return nil, fmt.Errorf("field %d,%d is not on the board", x, y)
+				}
+			}
+		}
+	}
+	return json.Marshal(jsonBoard{Width: js.Width, Height: js.Height, Rows: rows})
+}
+
+//validate returns an error, if the dimensions of jb do not match its rows
+func (jb jsonBoard) validate() error {
+	if jb.Width < 0 || jb.Height < 0 {
+		return fmt.Errorf("the board has the negative size %dx%d", jb.Width, jb.Height)
+	}
+	if len(jb.Rows) != jb.Height {
+		return fmt.Errorf("the board has %d rows instead of its height %d", len(jb.Rows), jb.Height)
+	}
+	for y, row := range jb.Rows {
+		if len(row) != jb.Width {
+			return fmt.Errorf("row %d has %d fields instead of the width %d", y+1, len(row), jb.Width)
+		}
+	}
+	return nil
+}
+
+//MarshalJSON implements the json.Marshaler interface.
+//The state is wrapped in an Envelope.
+func (s GameState) MarshalJSON() ([]byte, error) {
+	type plain GameState
+	return seal(KindGame, plain(s))
+}
+
+//UnmarshalJSON implements the json.Unmarshaler interface.
+//It accepts documents of all versions and rejects inconsistent states.
+func (s *GameState) UnmarshalJSON(doc []byte) error {
+	data, err := unseal(KindGame, doc)
+	if err != nil {
+		return err
+	}
+	type plain GameState
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	for i, pl := range p.Players {
+		if pl == nil {
+			return fmt.Errorf("player %d is missing", i+1)
+		}
+	}
+	if p.Turn < -1 || p.Turn >= len(p.Players) {
+		return fmt.Errorf("turn %d does not name a player", p.Turn)
+	}
+	for y, row := range p.Board {
+		if len(row) != len(p.Board[0]) {
+			return fmt.Errorf("row %d of the board has %d fields instead of %d", y+1, len(row), len(p.Board[0]))
+		}
+	}
+	for y, row := range p.Setup {
+		if len(row) != len(p.Setup[0]) {
+			return fmt.Errorf("row %d of the setup has %d fields instead of %d", y+1, len(row), len(p.Setup[0]))
+		}
+	}
+	*s = GameState(p)
+	return nil
+}
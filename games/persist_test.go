@@ -0,0 +1,93 @@
+package games
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBoardVersions(t *testing.T) {
+	b, _ := NewSimple2DBoard(3, 2)
+	b.Set(2, 1, "x")
+	j, err := json.Marshal(b)
+	if e := `{"version":2,"kind":"board","data":{"width":3,"height":2,"rows":[["","",""],["","","x"]]}}`; err != nil || string(j) != e {
+		t.Errorf("MarshalJSON failed. Returned %s (%v) expected %s", j, err, e)
+	}
+	var a Simple2DBoard
+	if err := json.Unmarshal(j, &a); err != nil || a.String() != b.String() || a.Hash() != b.Hash() {
+		t.Errorf("UnmarshalJSON failed. Returned\n%v\n(%v)", &a, err)
+	}
+
+	//unversioned boards of version 1 are migrated. NewSimple2DBoard(7, 6)
+	//created 7 rows of 6 fields back then, which Set and Get accessed as
+	//Board[y][x].
+	legacy := JSONSimple2DBoard{Width: 7, Height: 6, Board: make([][]string, 7)}
+	for i := range legacy.Board {
+		legacy.Board[i] = make([]string, 6)
+	}
+	legacy.Board[5][0] = "x"
+	legacy.Board[2][3] = "o"
+	v1, err := json.Marshal(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := mustPosition(t, "......./......./...o.../......./......./x......")
+	if err := json.Unmarshal(v1, &a); err != nil || a.String() != e.String() || a.Width() != 7 || a.Height() != 6 {
+		t.Errorf("UnmarshalJSON failed to migrate version 1. Returned\n%v\n(%v)", &a, err)
+	}
+	legacy = JSONSimple2DBoard{Width: 2, Height: 2, Board: [][]string{{"", "o"}, {"", ""}}}
+	v1, _ = json.Marshal(legacy)
+	if err := json.Unmarshal(v1, &a); err != nil || a.Get(1, 0) != "o" {
+		t.Errorf("UnmarshalJSON failed to migrate a square board of version 1. Returned\n%v\n(%v)", &a, err)
+	}
+
+	for _, invalid := range []string{
+		`{"Board":[["",""],["","",""]],"Width":3,"Height":2}`,
+		`{"Board":[["","",""]],"Width":3,"Height":2}`,
+		`{"Board":[["",""],["",""],["","x"]],"Width":3,"Height":2}`,
+		`{"Board":[],"Width":-1,"Height":0}`,
+		`{"version":2,"kind":"board","data":{"width":2,"height":1,"rows":[["","",""]]}}`,
+		`{"version":3,"kind":"board","data":{"width":0,"height":0,"rows":[]}}`,
+		`{"version":0,"kind":"board","data":{"width":0,"height":0,"rows":[]}}`,
+		`{"version":2,"kind":"game","data":{"width":0,"height":0,"rows":[]}}`,
+		`[]`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &a); err == nil {
+			t.Errorf("UnmarshalJSON failed. Accepted %s", invalid)
+		}
+	}
+}
+
+func TestGameStateVersions(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+
+	//a game saved before documents were versioned
+	v1 := `{"game":"tictactoe","players":[{"name":"Alice","symbol":"o"},{"name":"Bob","symbol":"x"}],"turn":1,
+		"board":[["","",""],["","o",""],["","",""]],"stats":{"o":{"movesMade":1,"turn":0},"x":{"movesMade":0,"turn":1}},
+		"history":[{"action":0,"player":{"name":"Alice","symbol":"o"},"coords":[1,1],"time":"2020-05-17T12:00:00Z"}]}`
+	var s GameState
+	if err := json.Unmarshal([]byte(v1), &s); err != nil {
+		t.Fatalf("UnmarshalJSON failed to migrate version 1. Returned %v", err)
+	}
+	g, _ := NewBaseLogic(mustBoard(3, 3), p1, p2)
+	if err := Resume(g, s); err != nil || g.WhoseTurn() != p2 {
+		t.Errorf("Resume failed for version 1. Returned %v", err)
+	}
+
+	j, err := json.Marshal(s)
+	if err != nil || !strings.HasPrefix(string(j), `{"version":2,"kind":"game","data":{"game":"tictactoe"`) {
+		t.Errorf("MarshalJSON failed. Returned %s (%v)", j, err)
+	}
+
+	for _, invalid := range []string{
+		strings.Replace(v1, `"turn":1,`, `"turn":2,`, 1),
+		strings.Replace(v1, `["","o",""]`, `["","o"]`, 1),
+		strings.Replace(v1, `{"name":"Bob","symbol":"x"}`, `null`, 1),
+		`{"version":2,"kind":"board","data":{}}`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &s); err == nil {
+			t.Errorf("UnmarshalJSON failed. Accepted %s", invalid)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package games
+
+import "fmt"
+
+//QubicLogic implements 3D tic-tac-toe on a cube shaped Board3D.
+//A line spanning the whole cube wins, which includes rows, columns,
+//pillars, the diagonals of every plane and the four space diagonals.
+type QubicLogic struct {
+	history
+	players []*Player
+	board   Board3D
+	lines   [][][3]int
+}
+
+//NewQubicLogic returns an initialized QubicLogic.
+//The classic game is played on a 4x4x4 board.
+func NewQubicLogic(b Board3D, players ...*Player) (*QubicLogic, error) {
+	if b.Width() != b.Height() || b.Width() != b.Depth() {
+		return nil, fmt.Errorf("the board must be a cube")
+	}
+	if players[0] == players[1] {
+		return nil, fmt.Errorf("you must supply two different players")
+	}
+	if players[0].Symbol == players[1].Symbol {
+		return nil, fmt.Errorf("the two players must not have the same symbol")
+	}
+	return &QubicLogic{players: players, board: b, lines: cubeLines(b.Width())}, nil
+}
+
+//cubeLines returns all lines of length n through a cube with edge length n
+func cubeLines(n int) [][][3]int {
+	var lines [][][3]int
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dz := -1; dz <= 1; dz++ {
+				//only use one of the two opposite directions
+				if dx < 0 || (dx == 0 && dy < 0) || (dx == 0 && dy == 0 && dz <= 0) {
+					continue
+				}
+				//a line starts at 0 for positive, n-1 for negative and
+				//anywhere for constant coordinates
+				for _, x := range lineStarts(n, dx) {
+					for _, y := range lineStarts(n, dy) {
+						for _, z := range lineStarts(n, dz) {
+							line := make([][3]int, n)
+							for i := range line {
+								line[i] = [3]int{x + i*dx, y + i*dy, z + i*dz}
+							}
+							lines = append(lines, line)
+						}
+					}
+				}
+			}
+		}
+	}
+	return lines
+}
+
+//lineStarts returns the possible start coordinates of a line of length n
+//that goes into direction d along one axis
+func lineStarts(n, d int) []int {
+	switch d {
+	case 1:
+		return []int{0}
+	case -1:
+		return []int{n - 1}
+	}
+	starts := make([]int, n)
+	for i := range starts {
+		starts[i] = i
+	}
+	return starts
+}
+
+//Lines returns the number of winning lines on the board
+func (q *QubicLogic) Lines() int {
+	return len(q.lines)
+}
+
+//BeginTurn implements the GameLogic interface
+func (q *QubicLogic) BeginTurn() {
+
+}
+
+//EndTurn implements the GameLogic interface
+func (q *QubicLogic) EndTurn() {
+
+}
+
+//gameBoard3D returns the board q is played on
+func (q *QubicLogic) gameBoard3D() Board3D {
+	return q.board
+}
+
+//Players implements the GameLogic interface
+func (q *QubicLogic) Players() []*Player {
+	return append([]*Player(nil), q.players...)
+}
+
+//WhoseTurn implements the GameLogic interface
+func (q *QubicLogic) WhoseTurn() *Player {
+	movesTotal := q.board.Width() * q.board.Height() * q.board.Depth()
+	return q.players[(movesTotal-q.MovesRemaining())%len(q.players)]
+}
+
+//LegalMoves implements the GameLogic interface
+func (q *QubicLogic) LegalMoves(p *Player) []Ply {
+	return legalPlies3D(q, p, q.board)
+}
+
+//MovesRemaining implements the GameLogic interface
+func (q *QubicLogic) MovesRemaining() int {
+	moves := 0
+	for z := 0; z < q.board.Depth(); z++ {
+		for y := 0; y < q.board.Height(); y++ {
+			for x := 0; x < q.board.Width(); x++ {
+				if q.board.IsEmpty(x, y, z) {
+					moves++
+				}
+			}
+		}
+	}
+	return moves
+}
+
+//IsOver implements the GameLogic interface
+func (q *QubicLogic) IsOver() bool {
+	return q.GetWinner() != nil || q.MovesRemaining() == 0
+}
+
+//IsLegal implements the GameLogic interface.
+//Only placing is allowed and it takes three coordinates x,y,z.
+func (q *QubicLogic) IsLegal(a Action, p *Player, coords ...int) bool {
+	if a != Place || len(coords) < 3 {
+		return false
+	}
+	x, y, z := coords[0], coords[1], coords[2]
+	if x < 0 || y < 0 || z < 0 || x >= q.board.Width() || y >= q.board.Height() || z >= q.board.Depth() {
+		return false
+	}
+	return q.board.IsEmpty(x, y, z)
+}
+
+//Apply implements the GameLogic interface
+func (q *QubicLogic) Apply(m Ply) error {
+	snapshot := func() func() { return snapshot3D(q.board) }
+	return q.record(q, m, snapshot, func() {
+		q.board.Set(m.Coords[0], m.Coords[1], m.Coords[2], m.Player.Symbol)
+	})
+}
+
+//GetWinner implements the GameLogic interface
+func (q *QubicLogic) GetWinner() *Player {
+	for _, line := range q.lines {
+		s := q.board.Get(line[0][0], line[0][1], line[0][2])
+		if s == "" {
+			continue
+		}
+		complete := true
+		for _, f := range line[1:] {
+			if q.board.Get(f[0], f[1], f[2]) != s {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			for _, p := range q.players {
+				if p.Symbol == s {
+					return p
+				}
+			}
+		}
+	}
+	return nil
+}
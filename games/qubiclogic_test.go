@@ -0,0 +1,107 @@
+package games
+
+import "testing"
+
+func TestNewQubicLogic(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple3DBoard(4, 4, 4)
+	q, err := NewQubicLogic(b, &p1, &p2)
+	if err != nil {
+		t.Errorf("NewQubicLogic failed. %v", err)
+	}
+	if a := q.Lines(); a != 76 {
+		t.Errorf("NewQubicLogic failed. Expected %d winning lines got %d", 76, a)
+	}
+
+	b3, _ := NewSimple3DBoard(3, 3, 3)
+	q3, _ := NewQubicLogic(b3, &p1, &p2)
+	if a := q3.Lines(); a != 49 {
+		t.Errorf("NewQubicLogic failed. Expected %d winning lines got %d", 49, a)
+	}
+
+	b1, _ := NewSimple3DBoard(4, 4, 3)
+	q1, _ := NewQubicLogic(b1, &p1, &p2)
+	if q1 != nil {
+		t.Error("NewQubicLogic failed. Accepted a board that is not a cube")
+	}
+
+	q2, _ := NewQubicLogic(b, &p1, &p1)
+	if q2 != nil {
+		t.Error("NewQubicLogic failed. Accepted the same player twice")
+	}
+}
+
+func TestQubicWhoseTurn(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple3DBoard(4, 4, 4)
+	q, _ := NewQubicLogic(b, &p1, &p2)
+
+	if a := q.WhoseTurn(); a != &p1 {
+		t.Errorf("WhoseTurn failed. Expected %v got %v", &p1, a)
+	}
+	b.Set(0, 0, 0, p1.Symbol)
+	if a := q.WhoseTurn(); a != &p2 {
+		t.Errorf("WhoseTurn failed. Expected %v got %v", &p2, a)
+	}
+	if a := q.MovesRemaining(); a != 63 {
+		t.Errorf("MovesRemaining failed. Expected %d got %d", 63, a)
+	}
+}
+
+func TestQubicIsLegal(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple3DBoard(4, 4, 4)
+	q, _ := NewQubicLogic(b, &p1, &p2)
+
+	if !q.IsLegal(Place, &p1, 1, 2, 3) {
+		t.Error("IsLegal failed. Placing on an empty field is not legal")
+	}
+	b.Set(1, 2, 3, p1.Symbol)
+	if q.IsLegal(Place, &p2, 1, 2, 3) {
+		t.Error("IsLegal failed. Placing on an occupied field is legal")
+	}
+	if q.IsLegal(Place, &p2, 1, 2) || q.IsLegal(Place, &p2, 1, 2, 4) {
+		t.Error("IsLegal failed. Placing without a valid layer is legal")
+	}
+	if q.IsLegal(Move, &p1, 1, 2, 3, 0, 0, 0) {
+		t.Error("IsLegal failed. Moving a piece is legal")
+	}
+}
+
+func TestQubicGetWinner(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	tests := []struct {
+		name string
+		line [][3]int
+	}{
+		{"row", [][3]int{{0, 1, 2}, {1, 1, 2}, {2, 1, 2}, {3, 1, 2}}},
+		{"pillar", [][3]int{{2, 3, 0}, {2, 3, 1}, {2, 3, 2}, {2, 3, 3}}},
+		{"vertical plane diagonal", [][3]int{{1, 0, 3}, {1, 1, 2}, {1, 2, 1}, {1, 3, 0}}},
+		{"space diagonal", [][3]int{{3, 0, 0}, {2, 1, 1}, {1, 2, 2}, {0, 3, 3}}},
+	}
+
+	for _, tc := range tests {
+		b, _ := NewSimple3DBoard(4, 4, 4)
+		q, _ := NewQubicLogic(b, &p1, &p2)
+		for i, f := range tc.line {
+			if q.GetWinner() != nil {
+				t.Errorf("GetWinner failed for %s. The game was won after %d pieces", tc.name, i)
+			}
+			b.Set(f[0], f[1], f[2], p2.Symbol)
+		}
+		if a := q.GetWinner(); a != &p2 {
+			t.Errorf("GetWinner failed for %s. Returned %v expected: %v\n%v", tc.name, a, &p2, b)
+		}
+		if !q.IsOver() {
+			t.Errorf("IsOver failed for %s. The game is not over after a complete line", tc.name)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package games
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Results of a GameRecord
+const (
+	FirstWins   = "1-0"
+	SecondWins  = "0-1"
+	DrawResult  = "1/2-1/2"
+	NotFinished = "*"
+)
+
+const (
+	//recordDate is the layout of the Date header
+	recordDate  = "2006.01.02"
+	unknownDate = "????.??.??"
+	//maxRecordCol is the number of columns that can be written as a letter
+	maxRecordCol = 'z' - 'a' + 1
+)
+
+//GameRecord is a game written down as text like a chess game in PGN.
+//A record starts with headers such as
+//
+//	[Game "Tic Tac Toe"]
+//	[Player1 "Alice"]
+//	[Symbol1 "o"]
+//	[Player2 "Bob"]
+//	[Symbol2 "x"]
+//	[Size "3x3"]
+//	[Position "o../.../..x"]
+//	[Date "2020.05.17"]
+//	[Result "1-0"]
+//
+//followed by the list of plies and the result, e.g.
+//
+//	1. b2 a1 2. c3 b1 3. a3 c1 4. b3 1-0
+//
+//A ply is written as its coordinates: the column as a letter starting
+//with a and the row as a number counted from the top starting with 1.
+//Plies with a single coordinate only name the column. A removal is
+//prefixed with x, a move joins both fields with a dash, e.g. a1-b2.
+//The Position header holds the pieces on the board before the first ply
+//in position notation. It is left out if the board was empty.
+//The numbers count the plies of the first player. They are ignored when
+//reading the list, as some games let a player conduct several plies in a
+//row.
+type GameRecord struct {
+	Game string
+	//Players are the players in the order of their turns
+	Players       []*Player
+	Width, Height int
+	//Start is the board before the first ply, nil if it was empty
+	Start  *Simple2DBoard
+	Date   time.Time
+	Result string
+	//Plies are the plies of the game. Plies read by ReadRecords have no
+	//player, which is assigned by the logic when they are replayed.
+	Plies []Ply
+}
+
+//NewGameRecord returns the record of game g named game
+func NewGameRecord(game string, g GameLogic) GameRecord {
+	r := GameRecord{Game: game, Players: g.Players(), Plies: g.History(), Result: NotFinished}
+	if bh, ok := g.(boardHolder); ok {
+		r.Width, r.Height = bh.gameBoard().Width(), bh.gameBoard().Height()
+		if start := StartPosition(g); !isEmptyBoard(start) {
+			r.Start = start
+		}
+	}
+	if len(r.Plies) > 0 {
+		r.Date = r.Plies[0].Time
+	}
+	if g.IsOver() {
+		r.Result = DrawResult
+		if w := g.GetWinner(); w != nil && len(r.Players) > 0 {
+			r.Result = SecondWins
+			if *w == *r.Players[0] {
+				r.Result = FirstWins
+			}
+		}
+	}
+	return r
+}
+
+//Replay sets up the start position of r and applies its plies to g, which
+//has to be a new game of the kind r was recorded from. Every ply is
+//conducted by the player whose turn it is.
+func (r GameRecord) Replay(g GameLogic) error {
+	if r.Start != nil {
+		if err := SetUp(g, r.Start); err != nil {
+			return err
+		}
+	}
+	for i, m := range r.Plies {
+		m.Player = g.WhoseTurn()
+		if m.Player == nil {
+			return fmt.Errorf("ply %d: it is nobody's turn", i+1)
+		}
+		if err := g.Apply(m); err != nil {
+			return fmt.Errorf("ply %d (%s): %v", i+1, FormatPly(m), err)
+		}
+	}
+	return nil
+}
+
+//FormatPly returns ply m in the notation of GameRecord, or an empty string
+//if it cannot be written in it
+func FormatPly(m Ply) string {
+	switch {
+	case m.Action == Move && len(m.Coords) == 4:
+		from, to := formatField(m.Coords[:2]), formatField(m.Coords[2:])
+		if from == "" || to == "" {
+			return ""
+		}
+		return from + "-" + to
+	case m.Action == Remove:
+		if f := formatField(m.Coords); f != "" {
+			return "x" + f
+		}
+	case m.Action == Place:
+		return formatField(m.Coords)
+	}
+	return ""
+}
+
+//formatField returns a field given by one or two coordinates, e.g. b3
+func formatField(coords []int) string {
+	if len(coords) < 1 || len(coords) > 2 || coords[0] < 0 || coords[0] >= maxRecordCol {
+		return ""
+	}
+	f := string(rune('a' + coords[0]))
+	if len(coords) == 2 {
+		if coords[1] < 0 {
+			return ""
+		}
+		f += strconv.Itoa(coords[1] + 1)
+	}
+	return f
+}
+
+//fieldPattern matches a field of a ply
+var fieldPattern = regexp.MustCompile(`^([a-z])([0-9]*)$`)
+
+//ParsePly returns the ply written as s in the notation of GameRecord.
+//The ply has no player.
+func ParsePly(s string) (Ply, error) {
+	m := Ply{Action: Place}
+	fields := []string{s}
+	switch {
+	case strings.Contains(s, "-"):
+		m.Action = Move
+		fields = strings.Split(s, "-")
+		if len(fields) != 2 {
+			return Ply{}, fmt.Errorf("ply %q: a move has two fields", s)
+		}
+	case len(s) > 1 && s[0] == 'x' && s[1] >= 'a' && s[1] <= 'z':
+		m.Action = Remove
+		fields[0] = s[1:]
+	}
+	for _, f := range fields {
+		match := fieldPattern.FindStringSubmatch(f)
+		if match == nil || (m.Action == Move && match[2] == "") {
+			return Ply{}, fmt.Errorf("ply %q: %q is not a field", s, f)
+		}
+		m.Coords = append(m.Coords, int(match[1][0]-'a'))
+		if match[2] != "" {
+			row, err := strconv.Atoi(match[2])
+			if err != nil || row < 1 {
+				return Ply{}, fmt.Errorf("ply %q: %q is not a row", s, match[2])
+			}
+			m.Coords = append(m.Coords, row-1)
+		}
+	}
+	return m, nil
+}
+
+//isResult returns true, if s is one of the results of a GameRecord
+func isResult(s string) bool {
+	return s == FirstWins || s == SecondWins || s == DrawResult || s == NotFinished
+}
+
+//WriteTo writes r in the text format to w
+func (r GameRecord) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	header := func(name, value string) {
+		fmt.Fprintf(&sb, "[%s %s]\n", name, strconv.Quote(value))
+	}
+	header("Game", r.Game)
+	for i, p := range r.Players {
+		header(fmt.Sprintf("Player%d", i+1), p.Name)
+		header(fmt.Sprintf("Symbol%d", i+1), p.Symbol)
+	}
+	if r.Width > 0 && r.Height > 0 {
+		header("Size", fmt.Sprintf("%dx%d", r.Width, r.Height))
+	}
+	if r.Start != nil {
+		position, err := FormatPosition(r.Start, "")
+		if err != nil {
+			return 0, fmt.Errorf("the start position can not be written: %v", err)
+		}
+		header("Position", position)
+	}
+	date := unknownDate
+	if !r.Date.IsZero() {
+		date = r.Date.Format(recordDate)
+	}
+	header("Date", date)
+	result := r.Result
+	if result == "" {
+		result = NotFinished
+	}
+	header("Result", result)
+	sb.WriteString("\n")
+
+	//plies are numbered whenever it is the turn of the first player again,
+	//further plies of the first player in a row are not
+	var line []string
+	number := 0
+	first := false
+	for _, m := range r.Plies {
+		ply := FormatPly(m)
+		if ply == "" {
+			return 0, fmt.Errorf("ply %v can not be written as text", m)
+		}
+		isFirst := m.Player != nil && len(r.Players) > 0 && *m.Player == *r.Players[0]
+		if isFirst && !first {
+			number++
+			line = append(line, fmt.Sprintf("%d.", number))
+		}
+		first = isFirst
+		line = append(line, ply)
+	}
+	line = append(line, result)
+	sb.WriteString(strings.Join(line, " ") + "\n\n")
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+//headerPattern matches a header line of a GameRecord
+var headerPattern = regexp.MustCompile(`^\[(\w+)\s+(".*")\]$`)
+
+//ReadRecords reads all game records from r, e.g. the games of a
+//tournament. Unknown headers are ignored. Records whose players are
+//missing or share a symbol are rejected.
+func ReadRecords(r io.Reader) ([]GameRecord, error) {
+	var records []GameRecord
+	var current *GameRecord
+	//inMoves is true once the list of plies of current has started
+	inMoves := false
+	players := make(map[int]*Player)
+
+	finish := func() error {
+		if current == nil {
+			return nil
+		}
+		for i := 1; players[i] != nil; i++ {
+			current.Players = append(current.Players, players[i])
+		}
+		if len(current.Players) != len(players) {
+			return fmt.Errorf("game %d: player %d is missing", len(records)+1, len(current.Players)+1)
+		}
+		if err := current.checkPlayers(); err != nil {
+			return fmt.Errorf("game %d: %v", len(records)+1, err)
+		}
+		records = append(records, *current)
+		current, inMoves, players = nil, false, make(map[int]*Player)
+		return nil
+	}
+	player := func(i int) *Player {
+		if players[i] == nil {
+			players[i] = new(Player)
+		}
+		return players[i]
+	}
+
+	s := bufio.NewScanner(r)
+	for lineNo := 1; s.Scan(); lineNo++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if inMoves {
+				if err := finish(); err != nil {
+					return records, fmt.Errorf("line %d: %v", lineNo, err)
+				}
+			}
+			if current == nil {
+				current = &GameRecord{Result: NotFinished}
+			}
+			match := headerPattern.FindStringSubmatch(line)
+			if match == nil {
+				return records, fmt.Errorf("line %d: %q is not a header", lineNo, line)
+			}
+			value, err := strconv.Unquote(match[2])
+			if err != nil {
+				return records, fmt.Errorf("line %d: %v", lineNo, err)
+			}
+			if err := current.setHeader(match[1], value, player); err != nil {
+				return records, fmt.Errorf("line %d: %v", lineNo, err)
+			}
+			continue
+		}
+
+		if current == nil {
+			current = &GameRecord{Result: NotFinished}
+		}
+		inMoves = true
+		for _, token := range strings.Fields(line) {
+			switch {
+			case strings.HasSuffix(token, ".") && isNumber(token[:len(token)-1]):
+				continue
+			case isResult(token):
+				current.Result = token
+				if err := finish(); err != nil {
+					return records, fmt.Errorf("line %d: %v", lineNo, err)
+				}
+			default:
+				m, err := ParsePly(token)
+				if err != nil {
+					return records, fmt.Errorf("line %d: %v", lineNo, err)
+				}
+				if current == nil {
+					return records, fmt.Errorf("line %d: ply %s after the result", lineNo, token)
+				}
+				current.Plies = append(current.Plies, m)
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return records, err
+	}
+	return records, finish()
+}
+
+//checkPlayers returns an error, if r has no players or two of them share
+//a symbol, so they can not play the game
+func (r GameRecord) checkPlayers() error {
+	if len(r.Players) == 0 {
+		return fmt.Errorf("the players are missing")
+	}
+	symbols := make(map[string]int)
+	for i, p := range r.Players {
+		if p.Symbol == "" {
+			return fmt.Errorf("player %d has no symbol", i+1)
+		}
+		if j, ok := symbols[p.Symbol]; ok {
+			return fmt.Errorf("players %d and %d share the symbol %s", j+1, i+1, p.Symbol)
+		}
+		symbols[p.Symbol] = i
+	}
+	return nil
+}
+
+//setHeader stores the value of header name in r. player returns the i-th
+//player of the record.
+func (r *GameRecord) setHeader(name, value string, player func(i int) *Player) error {
+	switch {
+	case name == "Game":
+		r.Game = value
+	case name == "Size":
+		if _, err := fmt.Sscanf(value, "%dx%d", &r.Width, &r.Height); err != nil {
+			return fmt.Errorf("size %q is not given as widthxheight", value)
+		}
+	case name == "Position":
+		b, toMove, err := ParsePosition(value)
+		if err != nil || toMove != "" {
+			return fmt.Errorf("position %q is not a board in position notation", value)
+		}
+		r.Start = b
+	case name == "Date":
+		if value == unknownDate {
+			return nil
+		}
+		d, err := time.Parse(recordDate, value)
+		if err != nil {
+			return fmt.Errorf("date %q is not given as %s", value, recordDate)
+		}
+		r.Date = d
+	case name == "Result":
+		if !isResult(value) {
+			return fmt.Errorf("unknown result %q", value)
+		}
+		r.Result = value
+	case strings.HasPrefix(name, "Player") && isNumber(name[len("Player"):]):
+		i, _ := strconv.Atoi(name[len("Player"):])
+		player(i).Name = value
+	case strings.HasPrefix(name, "Symbol") && isNumber(name[len("Symbol"):]):
+		i, _ := strconv.Atoi(name[len("Symbol"):])
+		player(i).Symbol = value
+	}
+	return nil
+}
+
+//isEmptyBoard returns true, if there is no piece on board b
+func isEmptyBoard(b Board) bool {
+	for y := 0; y < b.Height(); y++ {
+		for x := 0; x < b.Width(); x++ {
+			if !b.IsEmpty(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//isNumber returns true, if s consists of decimal digits only
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
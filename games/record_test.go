@@ -0,0 +1,159 @@
+package games
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePly(t *testing.T) {
+	for s, e := range map[string]Ply{
+		"b3":     {Action: Place, Coords: []int{1, 2}},
+		"d":      {Action: Place, Coords: []int{3}},
+		"xa1":    {Action: Remove, Coords: []int{0, 0}},
+		"a1-b12": {Action: Move, Coords: []int{0, 0, 1, 11}},
+	} {
+		a, err := ParsePly(s)
+		if err != nil || !reflect.DeepEqual(a, e) {
+			t.Errorf("ParsePly(%q) failed. Returned %v (%v) expected %v", s, a, err, e)
+		}
+		if f := FormatPly(a); f != s {
+			t.Errorf("FormatPly failed. Returned %q expected %q", f, s)
+		}
+	}
+	for _, s := range []string{"", "1a", "a0", "a-b", "a1-b2-c3", "B2"} {
+		if _, err := ParsePly(s); err == nil {
+			t.Errorf("ParsePly failed. Accepted %q", s)
+		}
+	}
+	if f := FormatPly(NewPly(Place, nil, 1, 2, 3)); f != "" {
+		t.Errorf("FormatPly failed. Returned %q for three coordinates", f)
+	}
+}
+
+func TestGameRecord(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob \"the builder\"", Symbol: "x"}
+	g, _ := NewBaseLogic(mustBoard(3, 3), p1, p2)
+	for _, c := range [][]int{{1, 1}, {0, 0}, {2, 2}, {1, 0}, {2, 0}, {0, 2}, {2, 1}} {
+		m := NewPly(Place, g.WhoseTurn(), c...)
+		m.Time = time.Date(2020, 5, 17, 12, 0, 0, 0, time.UTC)
+		if err := g.Apply(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := NewGameRecord("Tic Tac Toe", g)
+	if r.Result != FirstWins || r.Width != 3 || r.Height != 3 {
+		t.Errorf("NewGameRecord failed. Returned %+v", r)
+	}
+
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed. Returned %v", err)
+	}
+	e := `[Game "Tic Tac Toe"]
+[Player1 "Alice"]
+[Symbol1 "o"]
+[Player2 "Bob \"the builder\""]
+[Symbol2 "x"]
+[Size "3x3"]
+[Date "2020.05.17"]
+[Result "1-0"]
+
+1. b2 a1 2. c3 b1 3. c1 a3 4. c2 1-0
+`
+	if buf.String() != e+"\n" {
+		t.Errorf("WriteTo failed. Wrote\n%s\nexpected\n%s", buf.String(), e)
+	}
+
+	//a tournament archive with an unfinished second game
+	archive := buf.String() + "[Game \"Connect Four\"]\n[Event \"Club\"]\n[Player1 \"Bob\"]\n[Symbol1 \"x\"]\n[Player2 \"Alice\"]\n[Symbol2 \"o\"]\n\n1. d c 2.\nd *\n"
+	records, err := ReadRecords(strings.NewReader(archive))
+	if err != nil || len(records) != 2 {
+		t.Fatalf("ReadRecords failed. Returned %v (%v)", records, err)
+	}
+	a := records[0]
+	if a.Game != r.Game || a.Result != r.Result || a.Width != 3 || a.Height != 3 || !a.Date.Equal(time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ReadRecords failed. Returned %+v", a)
+	}
+	if len(a.Players) != 2 || *a.Players[0] != *p1 || *a.Players[1] != *p2 || len(a.Plies) != 7 {
+		t.Errorf("ReadRecords failed. Returned players %v and plies %v", a.Players, a.Plies)
+	}
+	if c := records[1]; c.Game != "Connect Four" || c.Result != NotFinished || len(c.Plies) != 3 || c.Plies[2].Coords[0] != 3 {
+		t.Errorf("ReadRecords failed. Returned %+v", c)
+	}
+
+	replayed, _ := NewBaseLogic(mustBoard(3, 3), p1, p2)
+	if err := a.Replay(replayed); err != nil || replayed.GetWinner() != p1 {
+		t.Errorf("Replay failed. Returned %v", err)
+	}
+
+	for _, s := range []string{"[Game Tic]\n", "[Size \"3\"]\n", "1. b2 z99z 1-0\n", "1. b2 1-0 c3\n", "[Result \"2-0\"]\n"} {
+		if _, err := ReadRecords(strings.NewReader(s)); err == nil {
+			t.Errorf("ReadRecords failed. Accepted %q", s)
+		}
+	}
+
+	//the players of a record have to be able to play the game
+	for _, players := range []string{
+		"[Player1 \"Alice\"]\n[Player2 \"Bob\"]\n",
+		"[Player1 \"Alice\"]\n[Symbol1 \"o\"]\n[Player2 \"Bob\"]\n[Symbol2 \"o\"]\n",
+		"[Player1 \"Alice\"]\n[Symbol1 \"o\"]\n[Player3 \"Bob\"]\n[Symbol3 \"x\"]\n",
+		"",
+	} {
+		s := "[Game \"Tic Tac Toe\"]\n" + players + "\n1. b2 *\n"
+		if _, err := ReadRecords(strings.NewReader(s)); err == nil {
+			t.Errorf("ReadRecords failed. Accepted the players of\n%s", s)
+		}
+	}
+}
+
+func TestGameRecordNumbers(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	//Alice closes a mill and removes a piece of Bob in a row
+	r := GameRecord{Game: "Nine Men's Morris", Players: []*Player{p1, p2}, Result: NotFinished, Plies: []Ply{
+		NewPly(Place, p1, 0, 0), NewPly(Place, p2, 6, 6), NewPly(Place, p1, 3, 0),
+		NewPly(Place, p2, 6, 3), NewPly(Place, p1, 6, 0), NewPly(Remove, p1, 6, 6), NewPly(Place, p2, 0, 6),
+	}}
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if e := "1. a1 g7 2. d1 g4 3. g1 xg7 a7 *\n\n"; !strings.HasSuffix(buf.String(), e) {
+		t.Errorf("WriteTo failed. Wrote\n%s\nexpected the plies %q", buf.String(), e)
+	}
+}
+
+func TestGameRecordPosition(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	g, _ := NewBaseLogic(mustBoard(3, 3), p1, p2)
+	if err := SetUp(g, mustPosition(t, "x.o/.o./...")); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Apply(NewPly(Place, p2, 2, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := NewGameRecord("Tic Tac Toe", g).WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed. Returned %v", err)
+	}
+	if !strings.Contains(buf.String(), "[Position \"x.o/.o./...\"]\n") {
+		t.Errorf("WriteTo failed. Wrote no start position\n%s", buf.String())
+	}
+	records, err := ReadRecords(&buf)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("ReadRecords failed. Returned %v (%v)", records, err)
+	}
+	replayed, _ := NewBaseLogic(mustBoard(3, 3), p1, p2)
+	if err := records[0].Replay(replayed); err != nil {
+		t.Fatalf("Replay failed. Returned %v", err)
+	}
+	if p, _ := FormatPosition(replayed.gameBoard(), ""); p != "x.o/.o./..x" {
+		t.Errorf("Replay failed. Reached %q", p)
+	}
+}
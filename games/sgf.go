@@ -0,0 +1,508 @@
+package games
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//Values of the GM property naming the type of game
+const (
+	SGFGo      = 1
+	SGFOthello = 2
+	//SGFGomoku is the type of Gomoku and Renju games
+	SGFGomoku = 4
+)
+
+//sgfDefaultSizes are the sizes of the boards of the game types whose root
+//node has no SZ property
+var sgfDefaultSizes = map[int]int{SGFGo: 19, SGFOthello: 8, SGFGomoku: 15}
+
+//SGFProperty is a property of an SGFNode, e.g. B[hh] or AB[aa][bb]
+type SGFProperty struct {
+	Ident  string
+	Values []string
+}
+
+//SGFNode is a node of a game tree in the Smart Game Format (SGF). Every
+//node but the root usually holds a move, e.g. B[hh], and may hold setup
+//properties (AB, AW, AE) and a comment (C). The first child continues the
+//main line, the others are variations.
+type SGFNode struct {
+	Properties []SGFProperty
+	Children   []*SGFNode
+}
+
+//Get returns the first value of property ident and whether n has it
+func (n *SGFNode) Get(ident string) (string, bool) {
+	for _, p := range n.Properties {
+		if p.Ident == ident && len(p.Values) > 0 {
+			return p.Values[0], true
+		}
+	}
+	return "", false
+}
+
+//Values returns all values of property ident
+func (n *SGFNode) Values(ident string) []string {
+	var values []string
+	for _, p := range n.Properties {
+		if p.Ident == ident {
+			values = append(values, p.Values...)
+		}
+	}
+	return values
+}
+
+//Set replaces the values of property ident by values
+func (n *SGFNode) Set(ident string, values ...string) {
+	for i, p := range n.Properties {
+		if p.Ident == ident {
+			n.Properties[i].Values = values
+			return
+		}
+	}
+	n.Properties = append(n.Properties, SGFProperty{ident, values})
+}
+
+//Line returns the nodes from n to the end of a line of play. At the i-th
+//branch the child variations[i] is followed, the main line is followed
+//at all further branches.
+func (n *SGFNode) Line(variations ...int) ([]*SGFNode, error) {
+	line := []*SGFNode{n}
+	for branch := 0; len(n.Children) > 0; {
+		next := 0
+		if len(n.Children) > 1 {
+			if branch < len(variations) {
+				next = variations[branch]
+			}
+			branch++
+		}
+		if next < 0 || next >= len(n.Children) {
+			return nil, fmt.Errorf("there is no variation %d after %d nodes", next, len(line))
+		}
+		n = n.Children[next]
+		line = append(line, n)
+	}
+	return line, nil
+}
+
+//Size returns the width and height of the board given by the SZ property
+//of root node n, e.g. SZ[15] or SZ[7:6]. Without it the default size of
+//the game type given by GM is returned, which is Go if GM is missing.
+func (n *SGFNode) Size() (width, height int, err error) {
+	sz, ok := n.Get("SZ")
+	if !ok {
+		gameType := SGFGo
+		if gm, ok := n.Get("GM"); ok {
+			if gameType, err = strconv.Atoi(gm); err != nil {
+				return 0, 0, fmt.Errorf("GM[%s] is not a game type", gm)
+			}
+		}
+		size, ok := sgfDefaultSizes[gameType]
+		if !ok {
+			return 0, 0, fmt.Errorf("the game type %d has no default size", gameType)
+		}
+		return size, size, nil
+	}
+	dims := strings.Split(sz, ":")
+	if len(dims) > 2 {
+		return 0, 0, fmt.Errorf("SZ[%s] is not a size", sz)
+	}
+	if width, err = strconv.Atoi(dims[0]); err == nil {
+		height = width
+		if len(dims) == 2 {
+			height, err = strconv.Atoi(dims[1])
+		}
+	}
+	if err != nil || width < 1 || height < 1 {
+		return 0, 0, fmt.Errorf("SZ[%s] is not a size", sz)
+	}
+	return width, height, nil
+}
+
+//sgfCoords returns the SGF point of field x,y, e.g. "ab" for 0,1
+func sgfCoords(x, y int) (string, error) {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	if x < 0 || y < 0 || x >= len(letters) || y >= len(letters) {
+		return "", fmt.Errorf("field %d,%d can not be written in SGF", x, y)
+	}
+	return string([]byte{letters[x], letters[y]}), nil
+}
+
+//parseSGFCoords returns the field of the SGF point s
+func parseSGFCoords(s string) (x, y int, err error) {
+	coord := func(c byte) int {
+		switch {
+		case c >= 'a' && c <= 'z':
+			return int(c - 'a')
+		case c >= 'A' && c <= 'Z':
+			return int(c-'A') + 26
+		}
+		return -1
+	}
+	if len(s) != 2 || coord(s[0]) < 0 || coord(s[1]) < 0 {
+		return 0, 0, fmt.Errorf("%q is not an SGF point", s)
+	}
+	return coord(s[0]), coord(s[1]), nil
+}
+
+//NewSGFGame returns the history of game g as an SGF game tree of the
+//given game type, e.g. SGFGomoku. The first player plays black (B), the
+//second one white (W). Pieces on the board before the first ply are
+//written as setup properties. comments[i] is written as the comment of
+//the node after i plies, so comments[0] belongs to the root.
+//Only games on a 2D board placing pieces can be written.
+func NewSGFGame(gameType int, g GameLogic, comments map[int]string) (*SGFNode, error) {
+	bh, ok := g.(boardHolder)
+	players := g.Players()
+	if !ok || len(players) != 2 {
+		return nil, fmt.Errorf("only games of two players on a 2D board can be written in SGF")
+	}
+	b := bh.gameBoard()
+	root := &SGFNode{}
+	root.Set("FF", "4")
+	root.Set("GM", strconv.Itoa(gameType))
+	if b.Width() == b.Height() {
+		root.Set("SZ", strconv.Itoa(b.Width()))
+	} else {
+		root.Set("SZ", fmt.Sprintf("%d:%d", b.Width(), b.Height()))
+	}
+	root.Set("PB", players[0].Name)
+	root.Set("PW", players[1].Name)
+
+	//the pieces on the board before the first ply are set up
+	start := StartPosition(g)
+	var black, white []string
+	for y := 0; y < start.Height(); y++ {
+		for x := 0; x < start.Width(); x++ {
+			s := start.Get(x, y)
+			if s != players[0].Symbol && s != players[1].Symbol {
+				continue
+			}
+			p, err := sgfCoords(x, y)
+			if err != nil {
+				return nil, err
+			}
+			if s == players[0].Symbol {
+				black = append(black, p)
+			} else {
+				white = append(white, p)
+			}
+		}
+	}
+	if len(black) > 0 {
+		root.Set("AB", black...)
+	}
+	if len(white) > 0 {
+		root.Set("AW", white...)
+	}
+	if c, ok := comments[0]; ok {
+		root.Set("C", c)
+	}
+
+	n := root
+	for i, m := range g.History() {
+		if m.Action != Place || len(m.Coords) != 2 {
+			return nil, fmt.Errorf("ply %d (%v) can not be written in SGF", i+1, m)
+		}
+		p, err := sgfCoords(m.Coords[0], m.Coords[1])
+		if err != nil {
+			return nil, err
+		}
+		child := &SGFNode{}
+		if *m.Player == *players[0] {
+			child.Set("B", p)
+		} else {
+			child.Set("W", p)
+		}
+		if c, ok := comments[i+1]; ok {
+			child.Set("C", c)
+		}
+		n.Children = append(n.Children, child)
+		n = child
+	}
+	return root, nil
+}
+
+//ApplySGF conducts the moves of line, e.g. returned by SGFNode.Line, on g,
+//which is played on board b. Setup properties are only supported before
+//the first move and become the start position of g, see SetUp. Black
+//moves are conducted by the first player of g and white ones by the
+//second. It returns the comments of the nodes by the number of plies
+//conducted before them as described for NewSGFGame.
+func ApplySGF(g GameLogic, b Board, line []*SGFNode) (map[int]string, error) {
+	players := g.Players()
+	if len(players) != 2 {
+		return nil, fmt.Errorf("SGF games have two players")
+	}
+	comments := make(map[int]string)
+	plies := 0
+	for i, n := range line {
+		setup := []struct {
+			ident, symbol string
+		}{{"AE", ""}, {"AB", players[0].Symbol}, {"AW", players[1].Symbol}}
+		var start *Simple2DBoard
+		for _, s := range setup {
+			for _, v := range n.Values(s.ident) {
+				x, y, err := parseSGFCoords(v)
+				if err == nil {
+					err = CheckBounds(b, x, y)
+				}
+				if err == nil && plies > 0 {
+					err = fmt.Errorf("pieces can only be set up before the first move")
+				}
+				if err == nil && start == nil {
+					if start = StartPosition(g); start == nil {
+						err = fmt.Errorf("the game is not played on a 2D board")
+					}
+				}
+				if err != nil {
+					return comments, fmt.Errorf("node %d: %s: %v", i+1, s.ident, err)
+				}
+				if s.symbol == "" {
+					start.Remove(x, y)
+				} else {
+					start.Set(x, y, s.symbol)
+				}
+			}
+		}
+		if start != nil {
+			if err := SetUp(g, start); err != nil {
+				return comments, fmt.Errorf("node %d: %v", i+1, err)
+			}
+		}
+
+		for j, color := range []string{"B", "W"} {
+			v, ok := n.Get(color)
+			if !ok {
+				continue
+			}
+			if v == "" || (v == "tt" && b.Width() <= 19 && b.Height() <= 19) {
+				return comments, fmt.Errorf("node %d: passing is not supported", i+1)
+			}
+			x, y, err := parseSGFCoords(v)
+			if err == nil {
+				err = g.Apply(NewPly(Place, players[j], x, y))
+			}
+			if err != nil {
+				return comments, fmt.Errorf("node %d: %s[%s]: %v", i+1, color, v, err)
+			}
+			plies++
+		}
+		if c, ok := n.Get("C"); ok {
+			comments[plies] = c
+		}
+	}
+	return comments, nil
+}
+
+//WriteSGF writes the game trees to w
+func WriteSGF(w io.Writer, trees ...*SGFNode) error {
+	bw := bufio.NewWriter(w)
+	for _, t := range trees {
+		writeSGFTree(bw, t)
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+//writeSGFTree writes the game tree starting at n in parentheses
+func writeSGFTree(w *bufio.Writer, n *SGFNode) {
+	w.WriteString("(")
+	for {
+		w.WriteString(";")
+		for _, p := range n.Properties {
+			w.WriteString(p.Ident)
+			for _, v := range p.Values {
+				w.WriteString("[")
+				w.WriteString(strings.NewReplacer(`\`, `\\`, `]`, `\]`).Replace(v))
+				w.WriteString("]")
+			}
+		}
+		if len(n.Children) != 1 {
+			break
+		}
+		n = n.Children[0]
+	}
+	for _, c := range n.Children {
+		writeSGFTree(w, c)
+	}
+	w.WriteString(")")
+}
+
+//sgfParser reads game trees from SGF text
+type sgfParser struct {
+	r   *bufio.Reader
+	pos int
+}
+
+//ReadSGF reads all game trees from the SGF collection r
+func ReadSGF(r io.Reader) ([]*SGFNode, error) {
+	p := &sgfParser{r: bufio.NewReader(r)}
+	var trees []*SGFNode
+	for {
+		c, err := p.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return trees, err
+		}
+		if c != '(' {
+			return trees, p.errorf("expected ( but found %q", c)
+		}
+		t, err := p.tree()
+		if err != nil {
+			return trees, err
+		}
+		trees = append(trees, t)
+	}
+	if len(trees) == 0 {
+		return nil, fmt.Errorf("no SGF game tree found")
+	}
+	return trees, nil
+}
+
+func (p *sgfParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("SGF byte %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+//read returns the next byte
+func (p *sgfParser) read() (byte, error) {
+	c, err := p.r.ReadByte()
+	if err == nil {
+		p.pos++
+	}
+	return c, err
+}
+
+//next returns the next byte that is not white space
+func (p *sgfParser) next() (byte, error) {
+	for {
+		c, err := p.read()
+		if err != nil || (c != ' ' && c != '\t' && c != '\n' && c != '\r') {
+			return c, err
+		}
+	}
+}
+
+//unread puts the last byte back
+func (p *sgfParser) unread() {
+	p.r.UnreadByte()
+	p.pos--
+}
+
+//eof turns io.EOF into an error about the unexpected end
+func (p *sgfParser) eof(err error) error {
+	if err == io.EOF {
+		return p.errorf("unexpected end")
+	}
+	return err
+}
+
+//tree reads a game tree after its opening parenthesis
+func (p *sgfParser) tree() (*SGFNode, error) {
+	var root, last *SGFNode
+	for {
+		c, err := p.next()
+		if err != nil {
+			return nil, p.eof(err)
+		}
+		switch {
+		case c == ';':
+			n, err := p.node()
+			if err != nil {
+				return nil, err
+			}
+			if root == nil {
+				root = n
+			} else {
+				last.Children = append(last.Children, n)
+			}
+			last = n
+		case c == '(' && last != nil:
+			t, err := p.tree()
+			if err != nil {
+				return nil, err
+			}
+			last.Children = append(last.Children, t)
+		case c == ')' && root != nil:
+			return root, nil
+		default:
+			return nil, p.errorf("unexpected %q", c)
+		}
+	}
+}
+
+//node reads the properties of a node after its semicolon
+func (p *sgfParser) node() (*SGFNode, error) {
+	n := &SGFNode{}
+	for {
+		c, err := p.next()
+		if err != nil {
+			return nil, p.eof(err)
+		}
+		if c < 'A' || c > 'Z' {
+			p.unread()
+			return n, nil
+		}
+		ident := []byte{c}
+		for {
+			if c, err = p.read(); err != nil {
+				return nil, p.eof(err)
+			}
+			if c < 'A' || c > 'Z' {
+				break
+			}
+			ident = append(ident, c)
+		}
+		p.unread()
+
+		var values []string
+		for {
+			if c, err = p.next(); err != nil {
+				return nil, p.eof(err)
+			}
+			if c != '[' {
+				p.unread()
+				break
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		if len(values) == 0 {
+			return nil, p.errorf("property %s has no value", ident)
+		}
+		n.Properties = append(n.Properties, SGFProperty{string(ident), values})
+	}
+}
+
+//value reads a property value after its opening bracket
+func (p *sgfParser) value() (string, error) {
+	var v []byte
+	for {
+		c, err := p.read()
+		if err != nil {
+			return "", p.eof(err)
+		}
+		switch c {
+		case ']':
+			return string(v), nil
+		case '\\':
+			if c, err = p.read(); err != nil {
+				return "", p.eof(err)
+			}
+			//an escaped line break is removed
+			if c == '\n' || c == '\r' {
+				continue
+			}
+		}
+		v = append(v, c)
+	}
+}
@@ -0,0 +1,125 @@
+package games
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSGFRoundTrip(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	b, _ := NewSimple2DBoard(15, 15)
+	g, _ := NewGomokuLogic(b, Freestyle, p1, p2)
+	for _, c := range [][]int{{7, 7}, {8, 8}, {7, 8}, {6, 6}} {
+		g.Apply(NewPly(Place, g.WhoseTurn(), c...))
+	}
+	comments := map[int]string{0: "a [test] game", 2: `black \ white`}
+
+	root, err := NewSGFGame(SGFGomoku, g, comments)
+	if err != nil {
+		t.Fatalf("NewSGFGame failed. Returned %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteSGF(&buf, root); err != nil {
+		t.Fatalf("WriteSGF failed. Returned %v", err)
+	}
+	e := `(;FF[4]GM[4]SZ[15]PB[Alice]PW[Bob]C[a [test\] game];B[hh];W[ii]C[black \\ white];B[hi];W[gg])` + "\n"
+	if buf.String() != e {
+		t.Errorf("WriteSGF failed. Wrote %s expected %s", buf.String(), e)
+	}
+
+	trees, err := ReadSGF(&buf)
+	if err != nil || len(trees) != 1 || !reflect.DeepEqual(trees[0], root) {
+		t.Fatalf("ReadSGF failed. Returned %v (%v)", trees, err)
+	}
+	line, _ := trees[0].Line()
+	rb, _ := NewSimple2DBoard(15, 15)
+	rg, _ := NewGomokuLogic(rb, Freestyle, p1, p2)
+	ac, err := ApplySGF(rg, rb, line)
+	if err != nil || !reflect.DeepEqual(ac, comments) || rb.String() != b.String() || len(rg.History()) != 4 {
+		t.Errorf("ApplySGF failed. Returned %v (%v)\n%v", ac, err, rb)
+	}
+}
+
+func TestSGFSize(t *testing.T) {
+	for sgf, e := range map[string]int{"(;GM[4])": 15, "(;GM[2])": 8, "(;FF[4])": 19} {
+		trees, err := ReadSGF(strings.NewReader(sgf))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w, h, err := trees[0].Size(); w != e || h != e || err != nil {
+			t.Errorf("Size failed for %s. Returned %dx%d (%v)", sgf, w, h, err)
+		}
+	}
+	for _, sgf := range []string{"(;SZ[0])", "(;SZ[a])", "(;SZ[3:4:5])", "(;GM[3])"} {
+		trees, err := ReadSGF(strings.NewReader(sgf))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := trees[0].Size(); err == nil {
+			t.Errorf("Size failed. Accepted %s", sgf)
+		}
+	}
+}
+
+func TestSGFVariations(t *testing.T) {
+	sgf := `(;FF[4]GM[4]SZ[5:4]AB[aa][bb]AW[cc]
+		;W[dd]C[first]
+		(;B[ab]
+			;W[ba])
+		(;B[ad]C[second
+line]))`
+	trees, err := ReadSGF(strings.NewReader(sgf + "(;SZ[3];B[bb])"))
+	if err != nil || len(trees) != 2 {
+		t.Fatalf("ReadSGF failed. Returned %v (%v)", trees, err)
+	}
+	root := trees[0]
+	if v, _ := root.Get("SZ"); v != "5:4" || !reflect.DeepEqual(root.Values("AB"), []string{"aa", "bb"}) {
+		t.Errorf("ReadSGF failed. Read the properties %v", root.Properties)
+	}
+	if w, h, err := root.Size(); w != 5 || h != 4 || err != nil {
+		t.Errorf("Size failed. Returned %dx%d (%v)", w, h, err)
+	}
+	if w, h, err := trees[1].Size(); w != 3 || h != 3 || err != nil {
+		t.Errorf("Size failed. Returned %dx%d (%v)", w, h, err)
+	}
+
+	main, err := root.Line()
+	if err != nil || len(main) != 4 {
+		t.Fatalf("Line failed. Returned %d nodes (%v)", len(main), err)
+	}
+	variation, err := root.Line(1)
+	if c, _ := variation[2].Get("C"); err != nil || len(variation) != 3 || c != "second\nline" {
+		t.Errorf("Line failed. Returned the variation %v (%v)", variation, err)
+	}
+	if _, err := root.Line(2); err == nil {
+		t.Errorf("Line failed. Followed a variation that does not exist")
+	}
+
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	b, _ := NewSimple2DBoard(5, 4)
+	g, _ := NewMNKLogic(b, 4, p1, p2)
+	comments, err := ApplySGF(g, b, variation)
+	if err != nil {
+		t.Fatalf("ApplySGF failed. Returned %v", err)
+	}
+	if s, _ := FormatPosition(b, ""); s != "o..../.o.../..x../o..x." || comments[1] != "first" || comments[2] != "second\nline" {
+		t.Errorf("ApplySGF failed. Set up %s with comments %v", s, comments)
+	}
+
+	//without the setup it is the turn of black, so white may not move first
+	b, _ = NewSimple2DBoard(5, 4)
+	g, _ = NewMNKLogic(b, 4, p1, p2)
+	if _, err := ApplySGF(g, b, main[1:]); err == nil {
+		t.Errorf("ApplySGF failed. Accepted a move out of turn")
+	}
+
+	for _, s := range []string{"", "(;B[hh]", "x", "(;B)", "(;B[hh]C[x)", "()", "(;b[hh])"} {
+		if _, err := ReadSGF(strings.NewReader(s)); err == nil {
+			t.Errorf("ReadSGF failed. Accepted %q", s)
+		}
+	}
+}
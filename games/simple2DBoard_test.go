@@ -2,6 +2,7 @@ package games
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -110,6 +111,14 @@ func TestReset(t *testing.T) {
 	if &b.board == nil {
 		t.Error("Reset failed. Board set to new array")
 	}
+
+	for y := 0; y < b.Height(); y++ {
+		for x := 0; x < b.Width(); x++ {
+			if !b.IsEmpty(x, y) {
+				t.Errorf("Reset failed. Field (%d,%d) is not empty", x, y)
+			}
+		}
+	}
 }
 
 func TestMarshalJSON(t *testing.T) {
@@ -131,9 +140,9 @@ func TestUnMarshalJSON(t *testing.T) {
 
 	e, _ = NewSimple2DBoard(3, 3)
 
-	e.board[0][0] = "x"
-	e.board[1][1] = "x"
-	e.board[2][2] = "x"
+	e.Set(0, 0, "x")
+	e.Set(1, 1, "x")
+	e.Set(2, 2, "x")
 
 	j := []byte(`{"Board":[
 		["x","",""],
@@ -155,3 +164,75 @@ func TestUnMarshalJSON(t *testing.T) {
 		t.Errorf("Unmarshaling failed: Board Width is incorrect. Exepected: %d Received: %d", ew, a.Width())
 	}
 }
+
+func TestNonSquareBoard(t *testing.T) {
+	t.Log("Creating 7x6 board")
+	b, _ := NewSimple2DBoard(7, 6)
+	b.Set(6, 5, "x")
+	if b.Get(6, 5) != "x" {
+		t.Error("Set failed in the bottom right corner of a non-square board")
+	}
+	if len(b.board) != 6 || len(b.board[0]) != 7 {
+		t.Errorf("Expected 6 rows of 7 fields, got %d rows of %d fields", len(b.board), len(b.board[0]))
+	}
+}
+
+func TestTrySet(t *testing.T) {
+	b, _ := NewSimple2DBoard(3, 3)
+	if err := b.TrySet(1, 2, "x"); err != nil || b.Get(1, 2) != "x" {
+		t.Errorf("TrySet failed. Returned %v", err)
+	}
+	if err := b.TrySet(1, 2, "o"); !errors.Is(err, ErrOccupied) || b.Get(1, 2) != "x" {
+		t.Errorf("TrySet failed. Expected ErrOccupied, got %v", err)
+	}
+	for _, c := range [][2]int{{5, 5}, {-1, 0}, {0, 3}, {3, 0}} {
+		if err := b.TrySet(c[0], c[1], "o"); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("TrySet failed for (%d,%d). Expected ErrOutOfBounds, got %v", c[0], c[1], err)
+		}
+	}
+}
+
+func TestTryGet(t *testing.T) {
+	b, _ := NewSimple2DBoard(3, 2)
+	b.Set(2, 1, "x")
+	if s, err := b.TryGet(2, 1); err != nil || s != "x" {
+		t.Errorf("TryGet failed. Returned %q (%v)", s, err)
+	}
+	var fe *FieldError
+	if _, err := b.TryGet(2, 2); !errors.As(err, &fe) || fe.X != 2 || fe.Y != 2 || fe.Err != ErrOutOfBounds {
+		t.Errorf("TryGet failed. Expected a FieldError for (2,2), got %v", err)
+	}
+}
+
+func TestTryRemove(t *testing.T) {
+	b, _ := NewSimple2DBoard(3, 3)
+	if err := b.TryRemove(0, 0); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TryRemove failed. Expected ErrEmpty, got %v", err)
+	}
+	b.Set(0, 0, "x")
+	if err := b.TryRemove(0, 0); err != nil || !b.IsEmpty(0, 0) {
+		t.Errorf("TryRemove failed. Returned %v", err)
+	}
+	if err := b.TryRemove(0, 9); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("TryRemove failed. Expected ErrOutOfBounds, got %v", err)
+	}
+}
+
+func TestTryMove(t *testing.T) {
+	b, _ := NewSimple2DBoard(3, 3)
+	b.Set(0, 0, "x")
+	b.Set(1, 1, "o")
+	if err := b.TryMove(0, 0, 1, 1); !errors.Is(err, ErrOccupied) || b.Get(0, 0) != "x" {
+		t.Errorf("TryMove failed. Expected ErrOccupied, got %v", err)
+	}
+	if err := b.TryMove(0, 0, 3, 3); !errors.Is(err, ErrOutOfBounds) || b.Get(0, 0) != "x" {
+		t.Errorf("TryMove failed. Expected ErrOutOfBounds, got %v", err)
+	}
+	if err := b.TryMove(2, 2, 2, 1); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TryMove failed. Expected ErrEmpty, got %v", err)
+	}
+	if err := b.TryMove(0, 0, 0, 1); err != nil || !b.IsEmpty(0, 0) || b.Get(0, 1) != "x" {
+		t.Errorf("TryMove failed. Returned %v", err)
+		t.Logf("\n%v\n", b)
+	}
+}
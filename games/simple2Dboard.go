@@ -5,14 +5,18 @@ import (
 	"fmt"
 )
 
-// Simple2DBoard is a simple 2D board with a 2D string array
+// Simple2DBoard is a simple 2D board with a 2D string array.
+// It keeps track of the Zobrist hash of its pieces.
 type Simple2DBoard struct {
-	board  [][]string
-	height int
-	width  int
+	board   [][]string
+	height  int
+	width   int
+	zobrist *Zobrist
+	hash    uint64
 }
 
-// JSONSimple2DBoard is used when generating a JSON representation
+// JSONSimple2DBoard is the JSON representation of a Simple2DBoard before
+// documents were versioned (version 1). Board[y][x] is the field x,y.
 type JSONSimple2DBoard struct {
 	Board  [][]string
 	Height int
@@ -20,21 +24,25 @@ type JSONSimple2DBoard struct {
 }
 
 func (js JSONSimple2DBoard) Simple2DBoard() Simple2DBoard {
-	return Simple2DBoard{board: js.Board, height: js.Height, width: js.Width}
+	b := Simple2DBoard{board: js.Board, height: js.Height, width: js.Width}
+	b.SetZobrist(DefaultZobrist)
+	return b
 }
 
-// NewSimple2DBoard initializes a Simple2DBoard given two dimensions
-func NewSimple2DBoard(m, n int) (*Simple2DBoard, error) {
-	if n < 0 || m < 0 {
+// NewSimple2DBoard initializes a Simple2DBoard given two dimensions,
+// the width (number of columns) and the height (number of rows)
+func NewSimple2DBoard(width, height int) (*Simple2DBoard, error) {
+	if height < 0 || width < 0 {
 		return nil, fmt.Errorf("Both width and height must be positive")
 	}
 	b := new(Simple2DBoard)
-	b.board = make([][]string, m)
+	b.board = make([][]string, height)
 	for i := range b.board {
-		b.board[i] = make([]string, n)
+		b.board[i] = make([]string, width)
 	}
-	b.height = n
-	b.width = m
+	b.height = height
+	b.width = width
+	b.zobrist = DefaultZobrist
 	return b, nil
 }
 
@@ -44,9 +52,36 @@ func NewJSONSimple2DBoard(s Simple2DBoard) JSONSimple2DBoard {
 
 // Set gaming piece at Position p
 func (b *Simple2DBoard) Set(x, y int, s string) {
+	b.hash ^= b.key(x, y, b.board[y][x]) ^ b.key(x, y, s)
 	b.board[y][x] = s
 }
 
+//key returns the Zobrist key of piece s at x,y. Empty fields do not
+//contribute to the hash.
+func (b *Simple2DBoard) key(x, y int, s string) uint64 {
+	if s == "" || b.zobrist == nil {
+		return 0
+	}
+	return b.zobrist.Key(s, x, y)
+}
+
+//Hash implements the Hasher interface.
+//It returns the Zobrist hash of all pieces on the board, which is
+//updated with every change of the board.
+func (b *Simple2DBoard) Hash() uint64 {
+	return b.hash
+}
+
+//SetZobrist changes the keys used for hashing and recomputes the hash
+func (b *Simple2DBoard) SetZobrist(z *Zobrist) {
+	b.zobrist, b.hash = z, 0
+	for y := range b.board {
+		for x, s := range b.board[y] {
+			b.hash ^= b.key(x, y, s)
+		}
+	}
+}
+
 // Get gaming piece Position p
 func (b *Simple2DBoard) Get(x, y int) string {
 	return b.board[y][x]
@@ -80,12 +115,70 @@ func (b *Simple2DBoard) Move(x1, y1, x2, y2 int) {
 	b.Set(x2, y2, s)
 }
 
+//TryGet works like Get, but returns an error instead of panicking
+//if x,y is not on the board
+func (b *Simple2DBoard) TryGet(x, y int) (string, error) {
+	if err := CheckBounds(b, x, y); err != nil {
+		return "", err
+	}
+	return b.Get(x, y), nil
+}
+
+//TrySet works like Set, but returns an error if x,y is not on the board
+//or already occupied
+func (b *Simple2DBoard) TrySet(x, y int, s string) error {
+	if err := CheckBounds(b, x, y); err != nil {
+		return err
+	}
+	if !b.IsEmpty(x, y) {
+		return &FieldError{x, y, ErrOccupied}
+	}
+	b.Set(x, y, s)
+	return nil
+}
+
+//TryRemove works like Remove, but returns an error if x,y is not on
+//the board or empty
+func (b *Simple2DBoard) TryRemove(x, y int) error {
+	if err := CheckBounds(b, x, y); err != nil {
+		return err
+	}
+	if b.IsEmpty(x, y) {
+		return &FieldError{x, y, ErrEmpty}
+	}
+	b.Remove(x, y)
+	return nil
+}
+
+//TryMove works like Move, but returns an error if either field is not
+//on the board, x1,y1 is empty or x2,y2 is occupied.
+//The board is left unchanged on error.
+func (b *Simple2DBoard) TryMove(x1, y1, x2, y2 int) error {
+	if err := CheckBounds(b, x2, y2); err != nil {
+		return err
+	}
+	s, err := b.TryGet(x1, y1)
+	if err != nil {
+		return err
+	}
+	if s == "" {
+		return &FieldError{x1, y1, ErrEmpty}
+	}
+	if !b.IsEmpty(x2, y2) {
+		return &FieldError{x2, y2, ErrOccupied}
+	}
+	b.Move(x1, y1, x2, y2)
+	return nil
+}
+
 // Reset board
 func (b *Simple2DBoard) Reset() {
-	b.board = make([][]string, cap(b.board))
 	for i := range b.board {
-		b.board[i] = make([]string, cap(b.board[0]))
+		for j := range b.board[i] {
+			b.board[i][j] = ""
+		}
 	}
+	b.hash = 0
 }
 
 func (b *Simple2DBoard) String() string {
@@ -103,16 +196,26 @@ func (b *Simple2DBoard) String() string {
 	return ret
 }
 
-// MarshalJSON implements the Marshaler interface
+// MarshalJSON implements the Marshaler interface.
+// The board is wrapped in an Envelope.
 func (s *Simple2DBoard) MarshalJSON() ([]byte, error) {
-	return json.Marshal(NewJSONSimple2DBoard(*s))
+	return seal(KindBoard, jsonBoard{Width: s.width, Height: s.height, Rows: s.board})
 }
 
-func (s *Simple2DBoard) UnmarshalJSON(data []byte) error {
-	var js JSONSimple2DBoard
-	if err := json.Unmarshal(data, &js); err != nil {
+// UnmarshalJSON implements the Unmarshaler interface. It accepts documents
+// of all versions and rejects boards whose rows do not match their size.
+func (s *Simple2DBoard) UnmarshalJSON(doc []byte) error {
+	data, err := unseal(KindBoard, doc)
+	if err != nil {
+		return err
+	}
+	var jb jsonBoard
+	if err := json.Unmarshal(data, &jb); err != nil {
+		return err
+	}
+	if err := jb.validate(); err != nil {
 		return err
 	}
-	*s = js.Simple2DBoard()
+	*s = JSONSimple2DBoard{Board: jb.Rows, Height: jb.Height, Width: jb.Width}.Simple2DBoard()
 	return nil
 }
@@ -0,0 +1,72 @@
+package games
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewSimple3DBoard(t *testing.T) {
+	t.Log("Creating 4x3x2 board")
+	b, _ := NewSimple3DBoard(4, 3, 2)
+	if b.Width() != 4 || b.Height() != 3 || b.Depth() != 2 {
+		t.Errorf("Dimensions incorrect after call to Init. Expected: 4x3x2, got %dx%dx%d", b.Width(), b.Height(), b.Depth())
+	}
+
+	b1, _ := NewSimple3DBoard(4, 4, -1)
+	if b1 != nil {
+		t.Error("The depth of a Simple3DBoard must not be negative")
+	}
+
+	b2, _ := NewSimple3DBoard(-1, 4, 4)
+	if b2 != nil {
+		t.Error("The width of a Simple3DBoard must not be negative")
+	}
+}
+
+func TestSimple3DBoardSet(t *testing.T) {
+	b, _ := NewSimple3DBoard(4, 4, 4)
+	b.Set(1, 2, 3, "x")
+	if b.Get(1, 2, 3) != "x" {
+		t.Error("Invalid result after call to Set")
+	}
+	if b.Layer(3).Get(1, 2) != "x" {
+		t.Error("Set did not place the piece on the correct layer")
+	}
+	if !b.IsEmpty(1, 2, 2) {
+		t.Error("Set placed the piece on more than one layer")
+	}
+}
+
+func TestSimple3DBoardMove(t *testing.T) {
+	b, _ := NewSimple3DBoard(4, 4, 4)
+	b.Set(0, 0, 0, "x")
+	b.Move(0, 0, 0, 3, 3, 3)
+	if !b.IsEmpty(0, 0, 0) {
+		t.Error("Move not successful: Original piece not removed.")
+	}
+	if b.Get(3, 3, 3) != "x" {
+		t.Error("Move not successful: New piece not placed.")
+	}
+}
+
+func TestSimple3DBoardReset(t *testing.T) {
+	b, _ := NewSimple3DBoard(4, 4, 4)
+	b.Set(0, 0, 0, "x")
+	b.Set(3, 3, 3, "o")
+	b.Reset()
+	if !b.IsEmpty(0, 0, 0) || !b.IsEmpty(3, 3, 3) {
+		t.Error("Reset failed. Pieces are still on the board")
+	}
+}
+
+func TestSimple3DBoardString(t *testing.T) {
+	b, _ := NewSimple3DBoard(2, 2, 2)
+	b.Set(1, 0, 1, "x")
+	e := "Layer 0:\n| || |\n| || |\nLayer 1:\n| ||x|\n| || |\n"
+	if a := b.String(); a != e {
+		t.Errorf("String failed. Expected:\n%s\ngot:\n%s", e, a)
+	}
+	if strings.Count(b.String(), "Layer") != b.Depth() {
+		t.Error("String failed. Not every layer was rendered")
+	}
+}
@@ -0,0 +1,91 @@
+package games
+
+import "fmt"
+
+// Simple3DBoard is a stack of Simple2DBoard layers
+type Simple3DBoard struct {
+	layers []*Simple2DBoard
+	height int
+	width  int
+}
+
+// NewSimple3DBoard initializes a Simple3DBoard given the width m,
+// the height n and the number of layers d
+func NewSimple3DBoard(m, n, d int) (*Simple3DBoard, error) {
+	if d < 0 {
+		return nil, fmt.Errorf("The depth must be positive")
+	}
+	b := &Simple3DBoard{layers: make([]*Simple2DBoard, d), height: n, width: m}
+	for z := range b.layers {
+		l, err := NewSimple2DBoard(m, n)
+		if err != nil {
+			return nil, err
+		}
+		b.layers[z] = l
+	}
+	return b, nil
+}
+
+// Layer returns the 2D board at depth z
+func (b *Simple3DBoard) Layer(z int) *Simple2DBoard {
+	return b.layers[z]
+}
+
+//Width implements the Board3D interface
+func (b *Simple3DBoard) Width() int {
+	return b.width
+}
+
+//Height implements the Board3D interface
+func (b *Simple3DBoard) Height() int {
+	return b.height
+}
+
+//Depth implements the Board3D interface
+func (b *Simple3DBoard) Depth() int {
+	return len(b.layers)
+}
+
+// Set gaming piece
+func (b *Simple3DBoard) Set(x, y, z int, s string) {
+	b.layers[z].Set(x, y, s)
+}
+
+// Get gaming piece
+func (b *Simple3DBoard) Get(x, y, z int) string {
+	return b.layers[z].Get(x, y)
+}
+
+//IsEmpty returns true is the board at position x,y,z
+//is not occupied by a game piece
+func (b *Simple3DBoard) IsEmpty(x, y, z int) bool {
+	return b.layers[z].IsEmpty(x, y)
+}
+
+// Remove gaming piece
+func (b *Simple3DBoard) Remove(x, y, z int) {
+	b.layers[z].Remove(x, y)
+}
+
+// Move gaming piece
+func (b *Simple3DBoard) Move(x1, y1, z1, x2, y2, z2 int) {
+	s := b.Get(x1, y1, z1)
+	b.Remove(x1, y1, z1)
+	b.Set(x2, y2, z2, s)
+}
+
+// Reset board
+func (b *Simple3DBoard) Reset() {
+	for _, l := range b.layers {
+		l.Reset()
+	}
+}
+
+// String renders the layers one below the other
+func (b *Simple3DBoard) String() string {
+	var ret string
+	for z, l := range b.layers {
+		ret += fmt.Sprintf("Layer %d:\n%v", z, l)
+	}
+	return ret
+}
@@ -0,0 +1,143 @@
+package games
+
+import (
+	"fmt"
+	"reflect"
+)
+
+//GameState is the complete state of a game that can be saved as JSON.
+//A game is resumed by replaying History on a new game of the same kind,
+//which reproduces the state the logic keeps internally. The other fields
+//allow to check the resumed game and to inspect the document.
+type GameState struct {
+	//Game names the kind of game. It is chosen by the application.
+	Game    string    `json:"game,omitempty"`
+	Players []*Player `json:"players"`
+	//Turn is the index of the player in Players whose turn it is
+	Turn int `json:"turn"`
+	//Board holds the symbols of a 2D board row by row
+	Board [][]string `json:"board,omitempty"`
+	//Setup holds the symbols of a 2D board before the first ply row by
+	//row, e.g. of a game started from a position
+	Setup [][]string `json:"setup,omitempty"`
+	//Board3D holds the symbols of a 3D board layer by layer
+	Board3D [][][]string            `json:"board3D,omitempty"`
+	Stats   map[string]*PlayerStats `json:"stats,omitempty"`
+	History []Ply                   `json:"history"`
+}
+
+//boardHolder is implemented by logics played on a 2D board
+type boardHolder interface {
+	gameBoard() Board
+}
+
+//BoardOf returns the 2D board game g is played on, e.g. for agents reading
+//the pieces on it. ok is false for games that are not played on a 2D
+//board. The board must not be changed, plies are conducted using Apply.
+func BoardOf(g GameLogic) (b Board, ok bool) {
+	bh, ok := g.(boardHolder)
+	if !ok {
+		return nil, false
+	}
+	return bh.gameBoard(), true
+}
+
+//board3DHolder is implemented by logics played on a 3D board
+type board3DHolder interface {
+	gameBoard3D() Board3D
+}
+
+//statsHolder is implemented by logics keeping PlayerStats
+type statsHolder interface {
+	playerStats() map[string]*PlayerStats
+}
+
+//NewGameState returns the current state of game g named game
+func NewGameState(game string, g GameLogic) GameState {
+	s := GameState{Game: game, Players: g.Players(), Turn: -1, History: g.History()}
+	if p := g.WhoseTurn(); p != nil {
+		for i, o := range s.Players {
+			if *o == *p {
+				s.Turn = i
+			}
+		}
+	}
+	if bh, ok := g.(boardHolder); ok {
+		s.Board = boardRows(bh.gameBoard())
+		s.Setup = boardRows(StartPosition(g))
+	}
+	if bh, ok := g.(board3DHolder); ok {
+		b := bh.gameBoard3D()
+		s.Board3D = make([][][]string, b.Depth())
+		for z := range s.Board3D {
+			s.Board3D[z] = make([][]string, b.Height())
+			for y := range s.Board3D[z] {
+				s.Board3D[z][y] = make([]string, b.Width())
+				for x := range s.Board3D[z][y] {
+					s.Board3D[z][y][x] = b.Get(x, y, z)
+				}
+			}
+		}
+	}
+	if sh, ok := g.(statsHolder); ok {
+		s.Stats = make(map[string]*PlayerStats)
+		for k, v := range sh.playerStats() {
+			st := *v
+			s.Stats[k] = &st
+		}
+	}
+	return s
+}
+
+//Resume sets up the start position of s and replays its history on g,
+//which has to be a new game of the kind s was saved from with the same
+//players. The plies are attributed to the players of g. It returns an
+//error, if the resumed game does not match s.
+func Resume(g GameLogic, s GameState) error {
+	players := g.Players()
+	if len(players) != len(s.Players) {
+		return fmt.Errorf("the game has %d players, but %d were saved", len(players), len(s.Players))
+	}
+	bySymbol := make(map[string]*Player)
+	for i, p := range players {
+		if s.Players[i] == nil || *s.Players[i] != *p {
+			return fmt.Errorf("player %d is %v, but %v was saved", i+1, p, s.Players[i])
+		}
+		bySymbol[p.Symbol] = p
+	}
+
+	if s.Setup != nil {
+		start, err := rowsBoard(s.Setup)
+		if err != nil {
+			return fmt.Errorf("setup: %v", err)
+		}
+		if err := SetUp(g, start); err != nil {
+			return err
+		}
+	}
+
+	plies := make([]Ply, len(s.History))
+	for i, m := range s.History {
+		if m.Player == nil || bySymbol[m.Player.Symbol] == nil {
+			return fmt.Errorf("ply %d has an unknown player", i+1)
+		}
+		m.Player = bySymbol[m.Player.Symbol]
+		plies[i] = m
+	}
+	if err := Replay(g, plies); err != nil {
+		return err
+	}
+
+	resumed := NewGameState(s.Game, g)
+	switch {
+	case resumed.Turn != s.Turn:
+		return fmt.Errorf("it is the turn of player %d instead of %d", resumed.Turn+1, s.Turn+1)
+	case s.Board != nil && !reflect.DeepEqual(resumed.Board, s.Board):
+		return fmt.Errorf("the board does not match the saved one")
+	case s.Board3D != nil && !reflect.DeepEqual(resumed.Board3D, s.Board3D):
+		return fmt.Errorf("the board does not match the saved one")
+	case s.Stats != nil && !reflect.DeepEqual(resumed.Stats, s.Stats):
+		return fmt.Errorf("the player stats do not match the saved ones")
+	}
+	return nil
+}
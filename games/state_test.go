@@ -0,0 +1,131 @@
+package games
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSaveResume(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+
+	for name, newGame := range map[string]func() GameLogic{
+		"tictactoe": func() GameLogic {
+			g, _ := NewBaseLogic(mustBoard(3, 3), p1, p2)
+			return g
+		},
+		"othello": func() GameLogic {
+			g, _ := NewOthelloLogic(mustBoard(8, 8), p1, p2)
+			return g
+		},
+		"ninemensmorris": func() GameLogic {
+			g, _ := NewNineMensMorrisLogic(NewMorrisBoard(), p1, p2)
+			return g
+		},
+		"ultimate": func() GameLogic {
+			b, _ := NewNestedBoard(3)
+			g, _ := NewUltimateLogic(b, p1, p2)
+			return g
+		},
+		"qubic": func() GameLogic {
+			b, _ := NewSimple3DBoard(4, 4, 4)
+			g, _ := NewQubicLogic(b, p1, p2)
+			return g
+		},
+	} {
+		g := newGame()
+		for i := 0; i < 5 && !g.IsOver(); i++ {
+			moves := g.LegalMoves(g.WhoseTurn())
+			if err := g.Apply(moves[(7*i)%len(moves)]); err != nil {
+				t.Fatalf("%s: Apply failed. Returned %v", name, err)
+			}
+		}
+
+		saved, err := json.Marshal(NewGameState(name, g))
+		if err != nil {
+			t.Fatalf("%s: Marshal failed. Returned %v", name, err)
+		}
+		var s GameState
+		if err := json.Unmarshal(saved, &s); err != nil {
+			t.Fatalf("%s: Unmarshal failed. Returned %v", name, err)
+		}
+		resumed := newGame()
+		if err := Resume(resumed, s); err != nil {
+			t.Fatalf("%s: Resume failed. Returned %v\n%s", name, err, saved)
+		}
+
+		again, _ := json.Marshal(NewGameState(name, resumed))
+		if !bytes.Equal(saved, again) {
+			t.Errorf("%s: Resume failed. Saved\n%s\nresumed\n%s", name, saved, again)
+		}
+		if h, ok := g.(Hasher); ok && h.Hash() != resumed.(Hasher).Hash() {
+			t.Errorf("%s: Resume failed. The hashes differ", name)
+		}
+		a, e := resumed.LegalMoves(resumed.WhoseTurn()), g.LegalMoves(g.WhoseTurn())
+		if len(a) != len(e) || resumed.Apply(a[0]) != nil || g.Apply(e[0]) != nil || resumed.GetWinner() != g.GetWinner() {
+			t.Errorf("%s: Resume failed. The resumed game continues differently", name)
+		}
+	}
+}
+
+func TestResumeMismatch(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	g, _ := NewBaseLogic(mustBoard(3, 3), p1, p2)
+	g.Apply(NewPly(Place, p1, 1, 1))
+	s := NewGameState("tictactoe", g)
+
+	fresh, _ := NewBaseLogic(mustBoard(3, 3), p2, p1)
+	if err := Resume(fresh, s); err == nil {
+		t.Errorf("Resume failed. Accepted swapped players")
+	}
+
+	s.Board[0][0] = p2.Symbol
+	fresh, _ = NewBaseLogic(mustBoard(3, 3), p1, p2)
+	if err := Resume(fresh, s); err == nil {
+		t.Errorf("Resume failed. Accepted a board not matching the history")
+	}
+
+	s = NewGameState("tictactoe", g)
+	s.History[0].Player = &Player{Name: "Eve", Symbol: "e"}
+	fresh, _ = NewBaseLogic(mustBoard(3, 3), p1, p2)
+	if err := Resume(fresh, s); err == nil {
+		t.Errorf("Resume failed. Accepted a ply of an unknown player")
+	}
+}
+
+func TestResumeSetup(t *testing.T) {
+	p1 := &Player{Name: "Alice", Symbol: "o"}
+	p2 := &Player{Name: "Bob", Symbol: "x"}
+	b := mustPosition(t, "x.o/.o./...")
+	g, _ := NewBaseLogic(b, p1, p2)
+	if err := g.Apply(NewPly(Place, p2, 2, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := json.Marshal(NewGameState("tictactoe", g))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s GameState
+	if err := json.Unmarshal(saved, &s); err != nil {
+		t.Fatal(err)
+	}
+	resumed, _ := NewBaseLogic(mustBoard(3, 3), p1, p2)
+	if err := Resume(resumed, s); err != nil {
+		t.Fatalf("Resume failed. Returned %v\n%s", err, saved)
+	}
+	if p, _ := FormatPosition(resumed.gameBoard(), ""); p != "x.o/.o./..x" {
+		t.Errorf("Resume failed. Set up %q", p)
+	}
+	if len(g.History()) != 1 {
+		t.Errorf("NewGameState failed. Changed the history to %v", g.History())
+	}
+
+	s.Setup[1][0] = "e"
+	resumed, _ = NewBaseLogic(mustBoard(3, 3), p1, p2)
+	if err := Resume(resumed, s); err == nil {
+		t.Errorf("Resume failed. Accepted a setup with an unknown symbol")
+	}
+}
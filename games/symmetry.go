@@ -0,0 +1,181 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+)
+
+//Symmetry is a rotation or reflection of a board.
+//Square boards have eight symmetries, which map every position onto an
+//equivalent one.
+type Symmetry int
+
+const (
+	Identity Symmetry = iota
+	//Rotate90 rotates the board clockwise by 90 degrees
+	Rotate90
+	Rotate180
+	Rotate270
+	//MirrorHorizontal mirrors the board at its vertical axis, swapping left and right
+	MirrorHorizontal
+	//MirrorVertical mirrors the board at its horizontal axis, swapping top and bottom
+	MirrorVertical
+	//Transpose mirrors the board at the diagonal from the top left corner
+	Transpose
+	//AntiTranspose mirrors the board at the diagonal from the top right corner
+	AntiTranspose
+)
+
+//Symmetries are all symmetries of a square board
+var Symmetries = []Symmetry{Identity, Rotate90, Rotate180, Rotate270, MirrorHorizontal, MirrorVertical, Transpose, AntiTranspose}
+
+//ErrNotSquare is returned for symmetries that require a square board
+var ErrNotSquare = errors.New("the board is not square")
+
+func (s Symmetry) String() string {
+	switch s {
+	case Identity:
+		return "identity"
+	case Rotate90:
+		return "rotate 90"
+	case Rotate180:
+		return "rotate 180"
+	case Rotate270:
+		return "rotate 270"
+	case MirrorHorizontal:
+		return "mirror horizontally"
+	case MirrorVertical:
+		return "mirror vertically"
+	case Transpose:
+		return "transpose"
+	case AntiTranspose:
+		return "anti-transpose"
+	default:
+		return fmt.Sprintf("symmetry %d", int(s))
+	}
+}
+
+//Apply returns the coordinates field x,y of a board with width w and
+//height h is moved to by s
+func (s Symmetry) Apply(x, y, w, h int) (int, int) {
+	switch s {
+	case Rotate90:
+		return h - 1 - y, x
+	case Rotate180:
+		return w - 1 - x, h - 1 - y
+	case Rotate270:
+		return y, w - 1 - x
+	case MirrorHorizontal:
+		return w - 1 - x, y
+	case MirrorVertical:
+		return x, h - 1 - y
+	case Transpose:
+		return y, x
+	case AntiTranspose:
+		return h - 1 - y, w - 1 - x
+	default:
+		return x, y
+	}
+}
+
+//Inverse returns the symmetry reverting s
+func (s Symmetry) Inverse() Symmetry {
+	switch s {
+	case Rotate90:
+		return Rotate270
+	case Rotate270:
+		return Rotate90
+	default:
+		//all other symmetries are their own inverse
+		return s
+	}
+}
+
+//swapsDimensions returns true, if s swaps the width and height of a board
+func (s Symmetry) swapsDimensions() bool {
+	return s == Rotate90 || s == Rotate270 || s == Transpose || s == AntiTranspose
+}
+
+//Size returns the width and height of a board with width w and height h
+//after applying s
+func (s Symmetry) Size(w, h int) (int, int) {
+	if s.swapsDimensions() {
+		return h, w
+	}
+	return w, h
+}
+
+//Ply returns ply m conducted on a board with width w and height h mapped
+//by s. Every pair of coordinates is mapped, so it works for placing,
+//removing and moving pieces.
+func (s Symmetry) Ply(m Ply, w, h int) Ply {
+	coords := make([]int, len(m.Coords))
+	copy(coords, m.Coords)
+	for i := 0; i+1 < len(coords); i += 2 {
+		coords[i], coords[i+1] = s.Apply(coords[i], coords[i+1], w, h)
+	}
+	m.Coords = coords
+	return m
+}
+
+//TransformBoard returns a new board holding the pieces of b mapped by s
+func TransformBoard(b Board, s Symmetry) *Simple2DBoard {
+	w, h := s.Size(b.Width(), b.Height())
+	t, _ := NewSimple2DBoard(w, h)
+	for y := 0; y < b.Height(); y++ {
+		for x := 0; x < b.Width(); x++ {
+			if p := b.Get(x, y); p != "" {
+				tx, ty := s.Apply(x, y, b.Width(), b.Height())
+				t.Set(tx, ty, p)
+			}
+		}
+	}
+	return t
+}
+
+//Rotate returns a copy of the board rotated clockwise by 90 degrees
+func (b *Simple2DBoard) Rotate() *Simple2DBoard {
+	return TransformBoard(b, Rotate90)
+}
+
+//Mirror returns a copy of the board with left and right swapped
+func (b *Simple2DBoard) Mirror() *Simple2DBoard {
+	return TransformBoard(b, MirrorHorizontal)
+}
+
+//Transpose returns a copy of the board mirrored at the diagonal from the
+//top left corner
+func (b *Simple2DBoard) Transpose() *Simple2DBoard {
+	return TransformBoard(b, Transpose)
+}
+
+//Canonical returns the canonical form of the square board b together with
+//the symmetry mapping b onto it. All equivalent positions have the same
+//canonical form: the one that is the smallest when comparing the fields
+//row by row. Plies on the canonical form are mapped back to b using the
+//inverse of the returned symmetry.
+func Canonical(b Board) (*Simple2DBoard, Symmetry, error) {
+	if b.Width() != b.Height() {
+		return nil, Identity, ErrNotSquare
+	}
+	best, bestSym := TransformBoard(b, Identity), Identity
+	for _, s := range Symmetries[1:] {
+		if t := TransformBoard(b, s); less(t, best) {
+			best, bestSym = t, s
+		}
+	}
+	return best, bestSym, nil
+}
+
+//less returns true, if the fields of a are smaller than the fields of b
+//when comparing them row by row. Both boards must have the same size.
+func less(a, b Board) bool {
+	for y := 0; y < a.Height(); y++ {
+		for x := 0; x < a.Width(); x++ {
+			if pa, pb := a.Get(x, y), b.Get(x, y); pa != pb {
+				return pa < pb
+			}
+		}
+	}
+	return false
+}
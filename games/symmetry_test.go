@@ -0,0 +1,106 @@
+package games
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSymmetryApply(t *testing.T) {
+	//where the top left corner of a 3x2 board ends up
+	tests := []struct {
+		s    Symmetry
+		x, y int
+	}{
+		{Identity, 0, 0},
+		{Rotate90, 1, 0},
+		{Rotate180, 2, 1},
+		{Rotate270, 0, 2},
+		{MirrorHorizontal, 2, 0},
+		{MirrorVertical, 0, 1},
+		{Transpose, 0, 0},
+		{AntiTranspose, 1, 2},
+	}
+	for _, tc := range tests {
+		if x, y := tc.s.Apply(0, 0, 3, 2); x != tc.x || y != tc.y {
+			t.Errorf("Apply failed for %v. Returned %d,%d expected: %d,%d", tc.s, x, y, tc.x, tc.y)
+		}
+	}
+
+	for _, s := range Symmetries {
+		w, h := s.Size(3, 2)
+		for y := 0; y < 2; y++ {
+			for x := 0; x < 3; x++ {
+				tx, ty := s.Apply(x, y, 3, 2)
+				if ix, iy := s.Inverse().Apply(tx, ty, w, h); ix != x || iy != y {
+					t.Errorf("Inverse failed for %v. Mapped %d,%d back to %d,%d", s, x, y, ix, iy)
+				}
+			}
+		}
+	}
+}
+
+func TestTransformBoard(t *testing.T) {
+	//x|o|
+	// | |
+	b, _ := NewSimple2DBoard(3, 2)
+	b.Set(0, 0, "x")
+	b.Set(1, 0, "o")
+
+	//x|
+	//o|
+	// |
+	r := b.Transpose()
+	if r.Width() != 2 || r.Height() != 3 || r.Get(0, 0) != "x" || r.Get(0, 1) != "o" {
+		t.Errorf("Transpose failed. Got:\n%v", r)
+	}
+
+	// |x
+	// |o
+	// |
+	r = b.Rotate()
+	if r.Get(1, 0) != "x" || r.Get(1, 1) != "o" {
+		t.Errorf("Rotate failed. Got:\n%v", r)
+	}
+	if r := r.Rotate().Rotate().Rotate(); !reflect.DeepEqual(r, b) {
+		t.Errorf("Rotate failed. Rotating four times returned:\n%v", r)
+	}
+
+	r = b.Mirror()
+	if r.Get(2, 0) != "x" || r.Get(1, 0) != "o" || !reflect.DeepEqual(r.Mirror(), b) {
+		t.Errorf("Mirror failed. Got:\n%v", r)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	b, _ := NewSimple2DBoard(3, 3)
+	b.Set(2, 0, "x")
+	b.Set(1, 1, "o")
+
+	c, s, err := Canonical(b)
+	if err != nil {
+		t.Fatalf("Canonical failed. %v", err)
+	}
+	for _, sym := range Symmetries {
+		e, _, _ := Canonical(TransformBoard(b, sym))
+		if !reflect.DeepEqual(c, e) || c.Hash() != e.Hash() {
+			t.Errorf("Canonical failed for %v. Got:\n%v\nexpected:\n%v", sym, e, c)
+		}
+	}
+	if !reflect.DeepEqual(TransformBoard(b, s), c) {
+		t.Errorf("Canonical failed. %v does not map the board onto its canonical form", s)
+	}
+
+	//a ply on the canonical form maps back to the original board
+	m := s.Inverse().Ply(NewPly(Place, nil, 0, 0), 3, 3)
+	if x, y := s.Apply(m.Coords[0], m.Coords[1], 3, 3); x != 0 || y != 0 {
+		t.Errorf("Ply failed. Mapped 0,0 to %v", m.Coords)
+	}
+	if mv := Rotate90.Ply(NewPly(Move, nil, 0, 0, 2, 1), 3, 3); !reflect.DeepEqual(mv.Coords, []int{2, 0, 1, 2}) {
+		t.Errorf("Ply failed. Mapped a move to %v", mv.Coords)
+	}
+
+	rect, _ := NewSimple2DBoard(7, 6)
+	if _, _, err := Canonical(rect); err != ErrNotSquare {
+		t.Errorf("Canonical failed. Expected ErrNotSquare, got %v", err)
+	}
+}
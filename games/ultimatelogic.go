@@ -0,0 +1,149 @@
+package games
+
+import "fmt"
+
+//UltimateLogic implements ultimate tic-tac-toe on a NestedBoard.
+//The field a player places a piece on decides on which sub-board the
+//opponent has to play next. Winning a sub-board claims the matching field
+//of the meta board and the game is won by a line on the meta board.
+type UltimateLogic struct {
+	*BaseLogic
+	nested *NestedBoard
+	subs   [][]*BaseLogic
+}
+
+//NewUltimateLogic returns an initialized UltimateLogic
+func NewUltimateLogic(b *NestedBoard, players ...*Player) (*UltimateLogic, error) {
+	bl, err := NewMNKLogic(b, b.Size(), players...)
+	if err != nil {
+		return nil, err
+	}
+	u := &UltimateLogic{BaseLogic: bl, nested: b}
+	u.subs = make([][]*BaseLogic, b.Size())
+	for y := range u.subs {
+		u.subs[y] = make([]*BaseLogic, b.Size())
+		for x := range u.subs[y] {
+			if u.subs[y][x], err = NewMNKLogic(b.Sub(x, y), b.Size(), players...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return u, nil
+}
+
+//SubWinner returns the player that has won the sub-board at
+//meta coordinates x,y, or nil if nobody has won it yet
+func (u *UltimateLogic) SubWinner(x, y int) *Player {
+	return u.subs[y][x].GetWinner()
+}
+
+//isDecided returns true, if the sub-board at meta coordinates x,y has
+//been won or has no empty fields left
+func (u *UltimateLogic) isDecided(x, y int) bool {
+	return u.subs[y][x].IsOver()
+}
+
+//NextBoard returns the meta coordinates of the sub-board the next
+//piece has to be placed on. ok is false if any undecided sub-board may be chosen.
+func (u *UltimateLogic) NextBoard() (x, y int, ok bool) {
+	lx, ly, placed := u.nested.LastSet()
+	if !placed {
+		return 0, 0, false
+	}
+	n := u.nested.Size()
+	x, y = lx%n, ly%n
+	if u.isDecided(x, y) {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+//MovesRemaining implements the GameLogic interface.
+//Only empty fields on undecided sub-boards are counted.
+func (u *UltimateLogic) MovesRemaining() int {
+	moves := 0
+	for y := range u.subs {
+		for x := range u.subs[y] {
+			if !u.isDecided(x, y) {
+				moves += u.subs[y][x].MovesRemaining()
+			}
+		}
+	}
+	return moves
+}
+
+//LegalMoves implements the GameLogic interface.
+//Only placements on the sub-board returned by NextBoard are legal.
+func (u *UltimateLogic) LegalMoves(p *Player) []Ply {
+	return legalPlies(u, p, u.nested, Place)
+}
+
+//IsOver implements the GameLogic interface
+func (u *UltimateLogic) IsOver() bool {
+	return u.GetWinner() != nil || u.MovesRemaining() == 0
+}
+
+//IsLegal implements the GameLogic interface.
+//Only placing is allowed and the global coordinates x,y must lie on the
+//sub-board returned by NextBoard.
+func (u *UltimateLogic) IsLegal(a Action, p *Player, coords ...int) bool {
+	return u.CheckLegal(a, p, coords...) == nil
+}
+
+//CheckLegal works like IsLegal, but returns the reason why an action
+//is not legal. It returns nil if the action is legal.
+func (u *UltimateLogic) CheckLegal(a Action, p *Player, coords ...int) error {
+	if a != Place || len(coords) < 2 {
+		return fmt.Errorf("pieces can only be placed in ultimate tic-tac-toe")
+	}
+	x, y := coords[0], coords[1]
+	if err := CheckBounds(u.nested, x, y); err != nil {
+		return err
+	}
+	n := u.nested.Size()
+	if nx, ny, ok := u.NextBoard(); ok && (x/n != nx || y/n != ny) {
+		return fmt.Errorf("the piece must be placed on sub-board (%d,%d)", nx, ny)
+	}
+	if u.isDecided(x/n, y/n) {
+		return fmt.Errorf("sub-board (%d,%d) has already been decided", x/n, y/n)
+	}
+	if !u.nested.IsEmpty(x, y) {
+		return &FieldError{x, y, ErrOccupied}
+	}
+	return nil
+}
+
+//snapshot returns a function restoring the board including its last
+//placed piece, which decides the next sub-board
+func (u *UltimateLogic) snapshot() func() {
+	restore, lx, ly := u.BaseLogic.snapshot(), u.nested.lastX, u.nested.lastY
+	return func() {
+		restore()
+		u.nested.lastX, u.nested.lastY = lx, ly
+	}
+}
+
+//Apply implements the GameLogic interface
+func (u *UltimateLogic) Apply(m Ply) error {
+	return u.record(u, m, u.snapshot, func() {
+		u.nested.Set(m.Coords[0], m.Coords[1], m.Player.Symbol)
+		u.stats[m.Player.Symbol].MovesMade++
+		u.EndTurn()
+	})
+}
+
+//GetWinner implements the GameLogic interface.
+//The meta board is built from the winners of the sub-boards on every call,
+//so reading the winner does not change the game.
+func (u *UltimateLogic) GetWinner() *Player {
+	n := u.nested.Size()
+	meta, _ := NewSimple2DBoard(n, n)
+	for y := range u.subs {
+		for x := range u.subs[y] {
+			if w := u.SubWinner(x, y); w != nil {
+				meta.Set(x, y, w.Symbol)
+			}
+		}
+	}
+	return (&BaseLogic{board: meta, winLength: n, players: u.players}).GetWinner()
+}
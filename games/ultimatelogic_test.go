@@ -0,0 +1,127 @@
+package games
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNewUltimateLogic(t *testing.T) {
+	b, _ := NewNestedBoard(3)
+
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	u, err := NewUltimateLogic(b, &p1, &p2)
+	if err != nil || len(u.subs) != 3 {
+		t.Errorf("NewUltimateLogic failed. Got %v (%v)", u, err)
+	}
+	if u.WhoseTurn() != &p1 {
+		t.Errorf("NewUltimateLogic failed. First player does not move first")
+	}
+	if a := u.MovesRemaining(); a != 81 {
+		t.Errorf("MovesRemaining failed. Expected %d got %d", 81, a)
+	}
+}
+
+func TestUltimateNextBoard(t *testing.T) {
+	b, _ := NewNestedBoard(3)
+
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	u, _ := NewUltimateLogic(b, &p1, &p2)
+
+	if _, _, ok := u.NextBoard(); ok {
+		t.Error("NextBoard failed. The first piece may be placed anywhere")
+	}
+
+	//placing in the top right field of the center board sends the
+	//opponent to the top right board
+	b.Set(5, 3, p1.Symbol)
+	if x, y, ok := u.NextBoard(); !ok || x != 2 || y != 0 {
+		t.Errorf("NextBoard failed. Returned (%d,%d,%t) expected (%d,%d,%t)", x, y, ok, 2, 0, true)
+	}
+	if !u.IsLegal(Place, &p2, 6, 0) {
+		t.Error("IsLegal failed. Placing on the required sub-board is not legal")
+	}
+	if u.IsLegal(Place, &p2, 0, 0) {
+		t.Error("IsLegal failed. Placing on another sub-board is legal")
+	}
+	if u.IsLegal(Place, &p2, 9, 0) {
+		t.Error("IsLegal failed. Placing outside the board is legal")
+	}
+	if u.IsLegal(Move, &p2, 5, 3, 6, 0) {
+		t.Error("IsLegal failed. Moving a piece is legal")
+	}
+
+	//once the top right board is won, the opponent may choose freely
+	setAll(b, p2.Symbol, 6, 0, 7, 0, 8, 0)
+	b.Set(2, 3, p1.Symbol)
+	if u.SubWinner(2, 0) != &p2 {
+		t.Errorf("SubWinner failed. Returned %v expected %v", u.SubWinner(2, 0), &p2)
+	}
+	if _, _, ok := u.NextBoard(); ok {
+		t.Error("NextBoard failed. A decided sub-board may not be required")
+	}
+	if u.IsLegal(Place, &p2, 6, 1) {
+		t.Error("IsLegal failed. Placing on a decided sub-board is legal")
+	}
+	if !u.IsLegal(Place, &p2, 0, 8) {
+		t.Error("IsLegal failed. Placing on any undecided sub-board is not legal")
+	}
+}
+
+func TestUltimateGetWinner(t *testing.T) {
+	b, _ := NewNestedBoard(3)
+
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	u, _ := NewUltimateLogic(b, &p1, &p2)
+
+	//a line in the first row of fields does not win the game
+	setAll(b, p1.Symbol, 2, 0, 3, 0, 4, 0)
+	if u.GetWinner() != nil || u.IsOver() {
+		t.Errorf("GetWinner failed. A line across sub-boards won the game:\n%v", b)
+	}
+
+	//win the three sub-boards on the diagonal
+	for i := 0; i < 3; i++ {
+		setAll(b, p2.Symbol, i*3, i*3, i*3+1, i*3+1, i*3+2, i*3+2)
+	}
+	if a := u.GetWinner(); a != &p2 {
+		t.Errorf("GetWinner failed. Returned %v expected %v\n%v", a, &p2, b)
+	}
+	if !u.IsOver() {
+		t.Error("IsOver failed. The game is not over after winning the meta board")
+	}
+
+	//reading the winner does not change the game
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if a := u.GetWinner(); a != &p2 {
+				t.Errorf("GetWinner failed. Returned %v concurrently expected %v", a, &p2)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestUltimateLegalMoves(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewNestedBoard(3)
+	u, _ := NewUltimateLogic(b, &p1, &p2)
+	if a := u.LegalMoves(&p1); len(a) != 81 {
+		t.Errorf("LegalMoves failed. Returned %d plies expected: %d", len(a), 81)
+	}
+	u.Apply(NewPly(Place, &p1, 4, 4))
+	a := u.LegalMoves(&p2)
+	if len(a) != 8 || hasPly(a, Place, 4, 4) || !hasPly(a, Place, 3, 3) {
+		t.Errorf("LegalMoves failed. Returned %v", a)
+	}
+}
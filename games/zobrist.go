@@ -0,0 +1,52 @@
+package games
+
+import "hash/fnv"
+
+//DefaultZobristSeed is the seed of the Zobrist keys used by boards by default
+const DefaultZobristSeed = 0x5a0b715
+
+//Zobrist derives the keys of Zobrist hashing from a seed.
+//The hash of a position is the XOR of the keys of all its pieces, so it can
+//be updated incrementally by XORing the keys of added and removed pieces.
+//Keys are computed from the seed instead of being drawn from a random
+//generator, so they are the same in every run and work for boards of any
+//size and pieces with any symbol.
+type Zobrist struct {
+	seed uint64
+}
+
+//NewZobrist returns a Zobrist deriving its keys from seed
+func NewZobrist(seed int64) *Zobrist {
+	return &Zobrist{seed: uint64(seed)}
+}
+
+//DefaultZobrist derives its keys from DefaultZobristSeed
+var DefaultZobrist = NewZobrist(DefaultZobristSeed)
+
+//Key returns the key of symbol s at the given coordinates,
+//e.g. Key(s, x, y) for a piece on a 2D board or Key(s) for the turn of
+//the player with symbol s
+func (z *Zobrist) Key(s string, coords ...int) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	k := mix(z.seed ^ h.Sum64())
+	for _, c := range coords {
+		k = mix(k ^ uint64(c))
+	}
+	return k
+}
+
+//mix scrambles the bits of k using the finalizer of splitmix64
+func mix(k uint64) uint64 {
+	k += 0x9e3779b97f4a7c15
+	k = (k ^ (k >> 30)) * 0xbf58476d1ce4e5b9
+	k = (k ^ (k >> 27)) * 0x94d049bb133111eb
+	return k ^ (k >> 31)
+}
+
+//Hasher is implemented by boards and game logics that can compute a
+//Zobrist hash of their current position
+type Hasher interface {
+	//Hash returns the same value for identical positions
+	Hash() uint64
+}
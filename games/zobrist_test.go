@@ -0,0 +1,120 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestZobristKey(t *testing.T) {
+	z := NewZobrist(42)
+	if z.Key("x", 1, 2) != NewZobrist(42).Key("x", 1, 2) {
+		t.Error("Key failed. Keys of the same seed differ")
+	}
+	if z.Key("x", 1, 2) == NewZobrist(43).Key("x", 1, 2) {
+		t.Error("Key failed. Keys of different seeds are equal")
+	}
+	keys := map[uint64]bool{}
+	for _, s := range []string{"x", "o"} {
+		for y := 0; y < 15; y++ {
+			for x := 0; x < 15; x++ {
+				keys[z.Key(s, x, y)] = true
+			}
+		}
+	}
+	if len(keys) != 2*15*15 {
+		t.Errorf("Key failed. Only %d of %d keys are distinct", len(keys), 2*15*15)
+	}
+
+	//keys must not change between runs, e.g. for opening books
+	if k := DefaultZobrist.Key("x", 0, 0); k != 0xebfabee350cf2718 {
+		t.Errorf("Key failed. Returned %#x", k)
+	}
+}
+
+func TestSimple2DBoardHash(t *testing.T) {
+	b, _ := NewSimple2DBoard(3, 3)
+	if b.Hash() != 0 {
+		t.Errorf("Hash failed. The empty board has hash %#x", b.Hash())
+	}
+	b.Set(0, 0, "x")
+	b.Set(1, 1, "o")
+
+	//the same position reached in a different order
+	b2, _ := NewSimple2DBoard(3, 3)
+	b2.Set(1, 1, "o")
+	b2.Set(2, 2, "x")
+	b2.Move(2, 2, 0, 0)
+	if b.Hash() != b2.Hash() {
+		t.Errorf("Hash failed. Identical positions have the hashes %#x and %#x", b.Hash(), b2.Hash())
+	}
+
+	h := b.Hash()
+	b.Set(0, 0, "o")
+	if b.Hash() == h {
+		t.Error("Hash failed. Replacing a piece did not change the hash")
+	}
+	b.Set(0, 0, "x")
+	b.Remove(1, 1)
+	b.Set(1, 1, "o")
+	if b.Hash() != h {
+		t.Error("Hash failed. Restoring a position did not restore its hash")
+	}
+
+	var a Simple2DBoard
+	j, _ := json.Marshal(b)
+	if err := json.Unmarshal(j, &a); err != nil || a.Hash() != h {
+		t.Errorf("Hash failed. The unmarshalled board has hash %#x expected: %#x", a.Hash(), h)
+	}
+
+	b.SetZobrist(NewZobrist(1))
+	if b.Hash() == h || b.Hash() != NewZobrist(1).Key("x", 0, 0)^NewZobrist(1).Key("o", 1, 1) {
+		t.Errorf("SetZobrist failed. The hash was not recomputed")
+	}
+
+	b.Reset()
+	if b.Hash() != 0 {
+		t.Errorf("Hash failed. The reset board has hash %#x", b.Hash())
+	}
+}
+
+func TestLogicHash(t *testing.T) {
+	p1 := Player{Name: "Alice", Symbol: "o"}
+	p2 := Player{Name: "Bob", Symbol: "x"}
+
+	b, _ := NewSimple2DBoard(3, 3)
+	l, _ := NewBaseLogic(b, &p1, &p2)
+	l.Apply(NewPly(Place, &p1, 0, 0))
+	l.Apply(NewPly(Place, &p2, 1, 1))
+	l.Apply(NewPly(Place, &p1, 2, 2))
+
+	b2, _ := NewSimple2DBoard(3, 3)
+	l2, _ := NewBaseLogic(b2, &p1, &p2)
+	l2.Apply(NewPly(Place, &p1, 2, 2))
+	l2.Apply(NewPly(Place, &p2, 1, 1))
+	l2.Apply(NewPly(Place, &p1, 0, 0))
+	if l.Hash() != l2.Hash() {
+		t.Error("Hash failed. Transposed plies result in different hashes")
+	}
+	if l.Hash() == b.Hash() {
+		t.Error("Hash failed. The turn is not part of the hash")
+	}
+
+	//the next sub-board is part of an ultimate tic-tac-toe position
+	nb, _ := NewNestedBoard(3)
+	u, _ := NewUltimateLogic(nb, &p1, &p2)
+	u.Apply(NewPly(Place, &p1, 4, 4))
+	u.Apply(NewPly(Place, &p2, 3, 3))
+	nb2, _ := NewNestedBoard(3)
+	u2, _ := NewUltimateLogic(nb2, &p1, &p2)
+	u2.Apply(NewPly(Place, &p1, 4, 4))
+	u2.Apply(NewPly(Place, &p2, 4, 3))
+	u2.Undo()
+	u2.Apply(NewPly(Place, &p2, 3, 3))
+	if u.Hash() != u2.Hash() {
+		t.Error("Hash failed. Identical ultimate positions have different hashes")
+	}
+	nb2.Set(0, 0, "")
+	if u.Hash() == u2.Hash() {
+		t.Error("Hash failed. The last placed piece is not part of the hash")
+	}
+}
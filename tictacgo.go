@@ -1,8 +1,19 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/er4z0r/tictacgo/ai"
 	. "github.com/er4z0r/tictacgo/games"
 )
 
@@ -10,41 +21,902 @@ func clear() {
 	fmt.Print("\033[H\033[2J")
 }
 
+//subBoardGame is implemented by game logics that restrict on which
+//sub-board the next piece has to be placed
+type subBoardGame interface {
+	NextBoard() (x, y int, ok bool)
+}
+
+//phasedGame is implemented by game logics where players move their
+//pieces once all of them have been placed
+type phasedGame interface {
+	NextAction(p *Player) Action
+}
+
+//scorer is implemented by game logics that decide the winner by score
+type scorer interface {
+	Score(p *Player) int
+}
+
+//game bundles everything the main loop needs to play a variant
+type game struct {
+	board fmt.Stringer
+	logic GameLogic
+	//prompt names the coordinates a player has to enter
+	prompt string
+	//coords is the number of coordinates a player has to enter
+	coords int
+	//depth is the number of plies a computer player looks ahead,
+	//0 searches the whole game
+	depth int
+	//agent plays for the computer instead of a Minimax searching depth plies
+	agent ai.Agent
+	//k is the win length of plain m,n,k-games, which can be looked up in
+	//a solver table, and 0 for all other games
+	k int
+	//variant is the number of the game in variants
+	variant int
+}
+
+//printableBoard is a 2D board that can be rendered as text
+type printableBoard interface {
+	Board
+	fmt.Stringer
+}
+
+//planar returns a game played by entering x,y on a 2D board
+func planar(b printableBoard, g GameLogic, depth int) game {
+	return game{board: b, logic: g, prompt: "coordinates", coords: 2, depth: depth}
+}
+
+//variants names the games that can be played, variant i+1 is variants[i].
+//The names are stored in saved games.
+var variants = []string{
+	"Tic Tac Toe",
+	"Gomoku (freestyle)",
+	"Gomoku (exactly five)",
+	"Renju",
+	"Connect Four",
+	"Ultimate Tic Tac Toe",
+	"Qubic (3D Tic Tac Toe)",
+	"Three Men's Morris",
+	"Achi",
+	"Nine Men's Morris",
+	"Othello",
+	"Misère Tic Tac Toe",
+	"Notakto",
+}
+
+//chooseVariant prompts for the variant to play
+func chooseVariant() int {
+	var variant int
+	fmt.Println("Choose a game:")
+	for i, v := range variants {
+		fmt.Printf("\t%d) %s\n", i+1, v)
+	}
+	fmt.Scan(&variant)
+	if variant < 1 || variant > len(variants) {
+		return 1
+	}
+	return variant
+}
+
+//newGame returns the given variant played by p1 and p2
+func newGame(variant int, p1, p2 *Player) (game, error) {
+	gm, err := newLogic(variant, p1, p2)
+	gm.variant = variant
+	return gm, err
+}
+
+//newLogic returns the board and logic of the given variant. It fails, if
+//p1 and p2 can not play a game together, e.g. as they share a symbol.
+func newLogic(variant int, p1, p2 *Player) (game, error) {
+	switch variant {
+	case 2, 3, 4:
+		newGomoku := func() (*Simple2DBoard, *GomokuLogic, error) {
+			b, err := NewSimple2DBoard(15, 15)
+			if err != nil {
+				return nil, nil, err
+			}
+			g, err := NewGomokuLogic(b, GomokuRule(variant-2), p1, p2)
+			return b, g, err
+		}
+		b, g, err := newGomoku()
+		if err != nil {
+			return game{}, err
+		}
+		gm := planar(b, g, 0)
+		//minimax can not search a board of this size in reasonable time
+		gm.agent = &ai.MCTS{Duration: 5 * time.Second, NewGame: func() (GameLogic, error) {
+			_, g, err := newGomoku()
+			return g, err
+		}}
+		return gm, nil
+	case 5:
+		b, err := NewSimple2DBoard(7, 6)
+		if err != nil {
+			return game{}, err
+		}
+		g, err := NewConnectFourLogic(b, p1, p2)
+		if err != nil {
+			return game{}, err
+		}
+		return game{board: b, logic: g, prompt: "a column", coords: 1, depth: 6}, nil
+	case 6:
+		b, err := NewNestedBoard(3)
+		if err != nil {
+			return game{}, err
+		}
+		g, err := NewUltimateLogic(b, p1, p2)
+		if err != nil {
+			return game{}, err
+		}
+		return planar(b, g, 4), nil
+	case 7:
+		b, err := NewSimple3DBoard(4, 4, 4)
+		if err != nil {
+			return game{}, err
+		}
+		g, err := NewQubicLogic(b, p1, p2)
+		if err != nil {
+			return game{}, err
+		}
+		return game{board: b, logic: g, prompt: "coordinates and layer", coords: 3, depth: 2}, nil
+	case 8, 9:
+		b, err := NewSimple2DBoard(3, 3)
+		if err != nil {
+			return game{}, err
+		}
+		newMorris := NewThreeMensMorrisLogic
+		if variant == 9 {
+			newMorris = NewAchiLogic
+		}
+		g, err := newMorris(b, p1, p2)
+		if err != nil {
+			return game{}, err
+		}
+		return planar(b, g, 6), nil
+	case 10:
+		b := NewMorrisBoard()
+		g, err := NewNineMensMorrisLogic(b, p1, p2)
+		if err != nil {
+			return game{}, err
+		}
+		return planar(b, g, 3), nil
+	case 11:
+		b, err := NewSimple2DBoard(8, 8)
+		if err != nil {
+			return game{}, err
+		}
+		g, err := NewOthelloLogic(b, p1, p2)
+		if err != nil {
+			return game{}, err
+		}
+		return planar(b, g, 4), nil
+	case 12:
+		b, err := NewSimple2DBoard(3, 3)
+		if err != nil {
+			return game{}, err
+		}
+		g, err := NewMisereLogic(b, DefaultWinLength, p1, p2)
+		if err != nil {
+			return game{}, err
+		}
+		return planar(b, g, 0), nil
+	case 13:
+		b, err := NewSimple3DBoard(3, 3, 3)
+		if err != nil {
+			return game{}, err
+		}
+		g, err := NewNotaktoLogic(b, p1, p2)
+		if err != nil {
+			return game{}, err
+		}
+		return game{board: b, logic: g, prompt: "coordinates and board", coords: 3, depth: 4}, nil
+	default:
+		b, err := NewSimple2DBoard(3, 3)
+		if err != nil {
+			return game{}, err
+		}
+		g, err := NewBaseLogic(b, p1, p2)
+		if err != nil {
+			return game{}, err
+		}
+		gm := planar(b, g, 0)
+		gm.k = DefaultWinLength
+		return gm, nil
+	}
+}
+
+//mnk returns the name of gm as used for Learner.Game, if it is a plain
+//m,n,k-game, and an empty string otherwise
+func (gm game) mnk() string {
+	b, ok := gm.board.(Board)
+	if gm.k == 0 || !ok {
+		return ""
+	}
+	return mnkName(b.Width(), b.Height(), gm.k)
+}
+
+//opponent returns a computer player of level l and style s for gm
+func (gm game) opponent(l ai.Level, s ai.Style) ai.Agent {
+	o := ai.NewOpponent(l, gm.depth, s, 0)
+	if gm.agent == nil {
+		return o
+	}
+	if l == ai.Hard {
+		return gm.agent
+	}
+	//the search of gm.agent cannot be limited, so only blunders weaken it
+	o.Agent = gm.agent
+	return o
+}
+
+//hinter returns the agent suggesting plies to human players of gm
+func (gm game) hinter() ai.Agent {
+	if gm.agent != nil {
+		return gm.agent
+	}
+	return ai.NewMinimax(gm.depth)
+}
+
+//analyzer returns the Minimax rating the plies of a finished game of gm.
+//Games played by a different agent are too large to be searched deeply,
+//so it only looks for plies allowing the opponent to win right away.
+func (gm game) analyzer() *ai.Minimax {
+	if gm.agent != nil {
+		return ai.NewMinimax(2)
+	}
+	return ai.NewMinimax(gm.depth)
+}
+
+//think returns the ply chosen by agent. For a Monte Carlo tree search
+//info describes the visit statistics of the ply.
+func think(agent ai.Agent, g GameLogic) (m Ply, info string, err error) {
+	mc, ok := agent.(*ai.MCTS)
+	if !ok {
+		m, err = agent.BestMove(g)
+		return m, "", err
+	}
+	stats, err := mc.Search(context.Background(), g)
+	if err != nil {
+		return m, "", err
+	}
+	return stats[0].Ply, fmt.Sprintf(" (%d visits, %.1f%% won)", stats[0].Visits, 100*stats[0].WinRate()), nil
+}
+
+//readCoords reads n coordinates from a single line of r.
+//Instead of coordinates the player may enter u to undo or r to redo
+//the last ply, h to ask for a hint or s to save the game, which is
+//returned as command. It returns an error if the line does not hold
+//n numbers.
+func readCoords(r io.Reader, n int) (coords []int, command string, err error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, "", err
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 1 {
+		switch fields[0] {
+		case "u", "r", "h", "s":
+			return nil, fields[0], nil
+		}
+	}
+	if len(fields) != n {
+		return nil, "", fmt.Errorf("please enter %d numbers separated by spaces", n)
+	}
+	coords = make([]int, n)
+	for i, f := range fields {
+		if coords[i], err = strconv.Atoi(f); err != nil {
+			return nil, "", fmt.Errorf("%s is not a number", f)
+		}
+	}
+	return coords, "", nil
+}
+
+//readLine reads r up to the end of the line. It reads a byte at a time,
+//so that no input is lost for the reads after it.
+func readLine(r io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				return string(line), nil
+			}
+			line = append(line, b[0])
+			continue
+		}
+		if err == io.EOF && len(line) > 0 {
+			return string(line), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+//formatCoords joins coordinates for messages, e.g. 1,2
+func formatCoords(coords []int) string {
+	return strings.Trim(strings.Replace(fmt.Sprint(coords), " ", ",", -1), "[]")
+}
+
+//solve solves the m,n,k-game given as "m,n,k" and writes the table to path
+func solve(mnk, path string) error {
+	var m, n, k int
+	if _, err := fmt.Sscanf(mnk, "%d,%d,%d", &m, &n, &k); err != nil {
+		return fmt.Errorf("the game has to be given as m,n,k: %v", err)
+	}
+	t, err := ai.Solve(m, n, k)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return t.Save(f)
+}
+
+//mnkName names the m,n,k-game played on a board of m by n fields with win
+//length k, e.g. for Learner.Game
+func mnkName(m, n, k int) string {
+	return fmt.Sprintf("%d,%d,%d", m, n, k)
+}
+
+//mnkGame returns a function creating the m,n,k-game given as "m,n,k" and
+//its name
+func mnkGame(mnk string) (func() (GameLogic, error), string, error) {
+	var m, n, k int
+	if _, err := fmt.Sscanf(mnk, "%d,%d,%d", &m, &n, &k); err != nil {
+		return nil, "", fmt.Errorf("the game has to be given as m,n,k: %v", err)
+	}
+	return func() (GameLogic, error) {
+		b, err := NewSimple2DBoard(m, n)
+		if err != nil {
+			return nil, err
+		}
+		return NewMNKLogic(b, k, &Player{Name: "Player1", Symbol: "o"}, &Player{Name: "Player2", Symbol: "x"})
+	}, mnkName(m, n, k), nil
+}
+
+//trainLearner lets the learner stored at path play games against itself
+//in the m,n,k-game given as "m,n,k" and stores it again. The learner at
+//path is created if it does not exist, otherwise it has to have been
+//trained on the same game. After every batch of games the results of the
+//learner against minimax searching depth plies are shown.
+func trainLearner(path, mnk string, games, every, depth int) error {
+	newGame, name, err := mnkGame(mnk)
+	if err != nil {
+		return err
+	}
+	l, err := loadLearner(path)
+	if os.IsNotExist(err) {
+		l, err = ai.NewLearner(), nil
+		l.Game = name
+	}
+	if err != nil {
+		return err
+	}
+	if l.Game != name {
+		return fmt.Errorf("the learner was trained on %s instead of %s", learnedGame(l), name)
+	}
+	if every <= 0 {
+		every = games
+	}
+
+	const evaluation = 100
+	fmt.Printf("Results of %d games against minimax of depth %d:\n", evaluation, depth)
+	fmt.Println("games\twins\tdraws\tlosses\tpositions")
+	mm := ai.NewMinimax(depth)
+	for trained := 0; trained < games; {
+		batch := every
+		if games-trained < batch {
+			batch = games - trained
+		}
+		if err := l.Train(newGame, batch); err != nil {
+			return err
+		}
+		trained += batch
+		rec, err := ai.Play(newGame, l, mm, evaluation)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d\t%d\t%d\t%d\t%d\n", trained, rec.Wins, rec.Draws, rec.Losses, l.Len())
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return l.Save(f)
+}
+
+//learnedGame names the game learner l was trained on for messages
+func learnedGame(l *ai.Learner) string {
+	if l.Game == "" {
+		return "an unknown game"
+	}
+	return "the m,n,k-game " + l.Game
+}
+
+//loadLearner reads the learner values at path
+func loadLearner(path string) (*ai.Learner, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ai.LoadLearner(f)
+}
+
+//setUp starts gm from the position written in position notation, see
+//SetUp. The side to move, if given, has to be the player whose turn it is.
+func setUp(gm game, position string) error {
+	p, toMove, err := ParsePosition(position)
+	if err != nil {
+		return err
+	}
+	if err := SetUp(gm.logic, p); err != nil {
+		return err
+	}
+	if w := gm.logic.WhoseTurn(); toMove != "" && (w == nil || w.Symbol != toMove) {
+		return fmt.Errorf("%s is not to move in this position", toMove)
+	}
+	return nil
+}
+
+//appendRecord appends the record of gm to the file at path
+func appendRecord(path string, gm game) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = NewGameRecord(variants[gm.variant-1], gm.logic).WriteTo(f)
+	return err
+}
+
+//replay steps through the game records at path ply by ply
+func replay(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	records, err := ReadRecords(f)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range records {
+		variant := 0
+		for j, v := range variants {
+			if v == r.Game {
+				variant = j + 1
+			}
+		}
+		if variant == 0 {
+			return fmt.Errorf("game %d: unknown game %q", i+1, r.Game)
+		}
+		if len(r.Players) != 2 {
+			return fmt.Errorf("game %d: a game needs two players", i+1)
+		}
+		gm, err := newGame(variant, r.Players[0], r.Players[1])
+		if err != nil {
+			return fmt.Errorf("game %d: %v", i+1, err)
+		}
+		if r.Width > 0 || r.Height > 0 {
+			b, ok := gm.board.(Board)
+			if !ok || b.Width() != r.Width || b.Height() != r.Height {
+				return fmt.Errorf("game %d: %s is not played on %dx%d fields", i+1, r.Game, r.Width, r.Height)
+			}
+		}
+		if r.Start != nil {
+			if err := SetUp(gm.logic, r.Start); err != nil {
+				return fmt.Errorf("game %d: %v", i+1, err)
+			}
+		}
+		title := fmt.Sprintf("Game %d of %d: %s, %s vs. %s", i+1, len(records), r.Game, r.Players[0].Name, r.Players[1].Name)
+
+		for j, m := range r.Plies {
+			m.Player = gm.logic.WhoseTurn()
+			if m.Player == nil {
+				return fmt.Errorf("game %d, ply %d: it is nobody's turn", i+1, j+1)
+			}
+			if err := gm.logic.Apply(m); err != nil {
+				return fmt.Errorf("game %d, ply %d (%s): %v", i+1, j+1, FormatPly(m), err)
+			}
+			clear()
+			fmt.Println(title)
+			fmt.Printf("\n%v\n", gm.board)
+			fmt.Printf("Ply %d of %d: %s plays %s.\n", j+1, len(r.Plies), m.Player.Name, FormatPly(m))
+			if quit := next(); quit {
+				return nil
+			}
+		}
+		fmt.Printf("Result: %s\n", r.Result)
+		if quit := next(); quit {
+			return nil
+		}
+	}
+	return nil
+}
+
+//next waits for the user to continue and returns true, if the user quits
+func next() bool {
+	var command string
+	fmt.Print("Press enter to continue (q: quit):")
+	fmt.Scanln(&command)
+	return command == "q"
+}
+
+//sgfTypes are the SGF game types of the variants that have one
+var sgfTypes = map[int]int{2: SGFGomoku, 3: SGFGomoku, 4: SGFGomoku, 11: SGFOthello}
+
+//loadSGF conducts the main line of the first game in the SGF file at path
+//on gm, whose board has to have the size of the game, and returns the
+//comment of the last position
+func loadSGF(gm game, path string) (string, error) {
+	b, ok := gm.board.(Board)
+	if !ok {
+		return "", fmt.Errorf("SGF games are played on a 2D board")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	trees, err := ReadSGF(f)
+	if err != nil {
+		return "", err
+	}
+	w, h, err := trees[0].Size()
+	if err != nil {
+		return "", err
+	}
+	if w != b.Width() || h != b.Height() {
+		return "", fmt.Errorf("the game is played on %dx%d fields, but %s on %dx%d", w, h, variants[gm.variant-1], b.Width(), b.Height())
+	}
+	line, err := trees[0].Line()
+	if err != nil {
+		return "", err
+	}
+	comments, err := ApplySGF(gm.logic, b, line)
+	return comments[len(gm.logic.History())], err
+}
+
+//saveSGF writes gm to the SGF file at path, if its variant has an SGF
+//game type
+func saveSGF(path string, gm game) error {
+	gameType, ok := sgfTypes[gm.variant]
+	if !ok {
+		return fmt.Errorf("SGF has no game type for %s", variants[gm.variant-1])
+	}
+	root, err := NewSGFGame(gameType, gm.logic, nil)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return WriteSGF(f, root)
+}
+
+//saveGame writes the state of gm to path as JSON
+func saveGame(path string, gm game) error {
+	data, err := json.MarshalIndent(NewGameState(variants[gm.variant-1], gm.logic), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+//loadGame resumes the game saved at path by saveGame
+func loadGame(path string) (game, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return game{}, err
+	}
+	var s GameState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return game{}, err
+	}
+	if len(s.Players) != 2 || s.Players[0] == nil || s.Players[1] == nil {
+		return game{}, fmt.Errorf("a game needs two players")
+	}
+	for i, v := range variants {
+		if v == s.Game {
+			gm, err := newGame(i+1, s.Players[0], s.Players[1])
+			if err != nil {
+				return game{}, err
+			}
+			return gm, Resume(gm.logic, s)
+		}
+	}
+	return game{}, fmt.Errorf("unknown game %q", s.Game)
+}
+
+//loadTable reads the solver table at path
+func loadTable(path string) (*ai.Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ai.LoadTable(f)
+}
+
+//analysis describes the theoretical value of the position on board b and
+//the best reply for player p according to table t
+func analysis(t *ai.Table, b Board, first, p *Player) string {
+	v, err := t.Lookup(b, first.Symbol)
+	if err != nil {
+		return ""
+	}
+	x, y, err := t.BestReply(b, first.Symbol)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("Theoretical value for %s: %v. Best reply: %d,%d", p.Name, v, x, y)
+}
+
 func main() {
+	tablePath := flag.String("table", "", "solver table to show the theoretical value of tic-tac-toe positions")
+	mnk := flag.String("solve", "", "solve the m,n,k-game given as m,n,k and write the table to the file given by -table")
+	learnerPath := flag.String("learner", "", "values of a self-play learner to play against or to train using -train")
+	train := flag.Int("train", 0, "number of games the learner given by -learner plays against itself")
+	trainGame := flag.String("train-game", "3,3,3", "m,n,k-game the learner is trained on")
+	every := flag.Int("every", 1000, "number of training games after which the learner is compared with minimax")
+	depth := flag.Int("depth", 0, "search depth of the minimax player the learner is compared with, 0 searches the whole game")
+	loadPath := flag.String("load", "", "saved game to resume")
+	replayPath := flag.String("replay", "", "game records to step through")
+	recordPath := flag.String("record", "", "file to append the record of the game to once it is over")
+	sgfIn := flag.String("sgf", "", "SGF file whose main line the game starts from")
+	sgfOut := flag.String("sgf-out", "", "SGF file to write the game to once it is over")
+	position := flag.String("position", "", "position to start the game from, e.g. \"x.o/.o./... x\"")
+	flag.Parse()
+
+	if *train > 0 {
+		if *learnerPath == "" {
+			fmt.Println("Please name the learner file using -learner.")
+			os.Exit(2)
+		}
+		if err := trainLearner(*learnerPath, *trainGame, *train, *every, *depth); err != nil {
+			fmt.Printf("Training failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *mnk != "" {
+		if *tablePath == "" {
+			fmt.Println("Please name the table file using -table.")
+			os.Exit(2)
+		}
+		if err := solve(*mnk, *tablePath); err != nil {
+			fmt.Printf("Solving %s failed: %v\n", *mnk, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *replayPath != "" {
+		if err := replay(*replayPath); err != nil {
+			fmt.Printf("Replaying %s failed: %v\n", *replayPath, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var learner *ai.Learner
+	if *learnerPath != "" {
+		var err error
+		if learner, err = loadLearner(*learnerPath); err != nil {
+			fmt.Printf("Loading the learner failed: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	var table *ai.Table
+	if *tablePath != "" {
+		var err error
+		if table, err = loadTable(*tablePath); err != nil {
+			fmt.Printf("Loading the table failed: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	fmt.Println("Hello Tic Tac Go!")
-	var x, y int
+	var msg string
 
-	b, _ := NewSimple2DBoard(3, 3)
+	var gm game
+	var p1, p2 *Player
+	if *loadPath != "" {
+		var err error
+		if gm, err = loadGame(*loadPath); err != nil {
+			fmt.Printf("Resuming the game failed: %v\n", err)
+			os.Exit(1)
+		}
+		players := gm.logic.Players()
+		p1, p2 = players[0], players[1]
+	} else {
+		//Prompt for player names
+		p1 = new(Player)
+		p2 = new(Player)
 
-	//Prompt for player names
-	p1 := new(Player)
-	p2 := new(Player)
+		fmt.Println("Player1 Name:")
+		fmt.Scan(&p1.Name)
+		p1.Symbol = "o"
 
-	fmt.Println("Player1 Name:")
-	fmt.Scan(&p1.Name)
-	p1.Symbol = "o"
+		fmt.Println("Player2 Name:")
+		fmt.Scan(&p2.Name)
+		p2.Symbol = "x"
 
-	fmt.Println("Player2 Name:")
-	fmt.Scan(&p2.Name)
-	p2.Symbol = "x"
+		var err error
+		if gm, err = newGame(chooseVariant(), p1, p2); err != nil {
+			fmt.Printf("Starting the game failed: %v\n", err)
+			os.Exit(1)
+		}
+		if *position != "" {
+			if err := setUp(gm, *position); err != nil {
+				fmt.Printf("Starting from position %q failed: %v\n", *position, err)
+				os.Exit(1)
+			}
+		}
+		if *sgfIn != "" {
+			comment, err := loadSGF(gm, *sgfIn)
+			if err != nil {
+				fmt.Printf("Loading %s failed: %v\n", *sgfIn, err)
+				os.Exit(1)
+			}
+			msg = comment
+		}
+	}
+	b, g := gm.board, gm.logic
+	if _, ok := sgfTypes[gm.variant]; *sgfOut != "" && !ok {
+		fmt.Printf("%s can not be written in SGF, it has no SGF game type.\n", variants[gm.variant-1])
+		os.Exit(2)
+	}
+	if learner != nil && (gm.mnk() == "" || learner.Game != gm.mnk()) {
+		fmt.Printf("The learner was trained on %s and can not play %s.\n", learnedGame(learner), variants[gm.variant-1])
+		learner = nil
+	}
 
-	var g GameLogic
-	g, _ = NewBaseLogic(b, p1, p2)
+	//Prompt for the players controlled by the computer
+	agents := make(map[*Player]ai.Agent)
+	for _, p := range []*Player{p1, p2} {
+		var level int
+		if learner != nil {
+			fmt.Printf("Who plays %s? (0: human, 1: easy, 2: medium, 3: hard, 4: learned computer):\n", p.Name)
+		} else {
+			fmt.Printf("Who plays %s? (0: human, 1: easy, 2: medium, 3: hard computer):\n", p.Name)
+		}
+		fmt.Scan(&level)
+		if level == 4 && learner != nil {
+			agents[p] = learner
+			continue
+		}
+		if level < 1 || level > 3 {
+			continue
+		}
+		var style int
+		fmt.Println("Style (0: balanced, 1: blocking, 2: central):")
+		fmt.Scan(&style)
+		agents[p] = gm.opponent(ai.Level(level-1), ai.Style(style))
+	}
 	for {
 		clear()
 		fmt.Printf("\n%v\n", b)
+		if msg != "" {
+			fmt.Println(msg)
+			msg = ""
+		}
 
 		//While the game is not over
 		if g.IsOver() {
 			break
 		}
 		p := g.WhoseTurn()
+
+		if sg, ok := g.(subBoardGame); ok {
+			if sx, sy, ok := sg.NextBoard(); ok {
+				fmt.Printf("Play on sub-board %d,%d.\n", sx, sy)
+			}
+		}
+		if pb, ok := b.(Board); ok && table != nil && gm.k == table.K {
+			if a := analysis(table, pb, p1, p); a != "" {
+				fmt.Println(a)
+			}
+		}
+
+		g.BeginTurn()
+		a := Place
+		if pg, ok := g.(phasedGame); ok {
+			a = pg.NextAction(p)
+		}
+
+		if agent, ok := agents[p]; ok {
+			fmt.Printf("%s is thinking...\n", p.Name)
+			m, info, err := think(agent, g)
+			if err == nil {
+				err = g.Apply(m)
+			}
+			if err != nil {
+				fmt.Printf("%s could not find a move: %v\n", p.Name, err)
+				return
+			}
+			msg = fmt.Sprintf("%s plays %s.%s", p.Name, formatCoords(m.Coords), info)
+			continue
+		}
+
 		//Prompt Player for coordinates
-		fmt.Printf("%s's turn. Please enter coordinates:", p.Name)
-		fmt.Scanln(&x, &y)
+		var coords []int
+		var command string
+		var err error
+		switch a {
+		case Move:
+			fmt.Printf("%s's turn. Please enter from and to coordinates (u: undo, r: redo, h: hint, s: save):", p.Name)
+			coords, command, err = readCoords(os.Stdin, 4)
+		case Remove:
+			fmt.Printf("%s's turn. Please enter the coordinates of the piece to remove (u: undo, r: redo, h: hint, s: save):", p.Name)
+			coords, command, err = readCoords(os.Stdin, 2)
+		default:
+			fmt.Printf("%s's turn. Please enter %s (u: undo, r: redo, h: hint, s: save):", p.Name, gm.prompt)
+			coords, command, err = readCoords(os.Stdin, gm.coords)
+		}
+		if err == io.EOF {
+			fmt.Println()
+			return
+		}
+		if err != nil {
+			msg = fmt.Sprintf("Your input was not understood: %v. Please try again.", err)
+			continue
+		}
 
-		if g.IsLegal(Place, p, x, y) {
-			b.Set(x, y, p.Symbol)
+		switch command {
+		case "u":
+			if err := g.Undo(); err != nil {
+				msg = fmt.Sprintf("You may not undo: %v", err)
+			}
+			continue
+		case "r":
+			if err := g.Redo(); err != nil {
+				msg = fmt.Sprintf("You may not redo: %v", err)
+			}
+			continue
+		case "h":
+			fmt.Println("Thinking...")
+			if h, err := ai.Suggest(g, gm.hinter()); err != nil {
+				msg = fmt.Sprintf("There is no hint: %v", err)
+			} else {
+				msg = fmt.Sprintf("Hint: %v.", h)
+			}
+			continue
+		case "s":
+			var path string
+			fmt.Println("File name:")
+			fmt.Scanln(&path)
+			if err := saveGame(path, gm); err != nil {
+				msg = fmt.Sprintf("Saving the game failed: %v", err)
+			} else {
+				msg = fmt.Sprintf("Saved the game to %s. Resume it using -load %s.", path, path)
+			}
+			continue
+		}
+
+		if err := g.Apply(NewPly(a, p, coords...)); err != nil {
+			var fe *FieldError
+			if errors.As(err, &fe) && errors.Is(err, ErrOutOfBounds) {
+				msg = fmt.Sprintf("Field %d,%d is not on the board. Please try again.", fe.X, fe.Y)
+			} else if a == Move {
+				msg = fmt.Sprintf("You may not move a piece from %s to %s: %v", formatCoords(coords[:2]), formatCoords(coords[2:]), err)
+			} else {
+				msg = fmt.Sprintf("You may not %v a piece at %s: %v", a, formatCoords(coords), err)
+			}
 		}
 	}
 
@@ -52,10 +924,52 @@ func main() {
 	clear()
 	fmt.Println("\t== GAME OVER! ==")
 	fmt.Printf("\n%v\n", b)
+	if sc, ok := g.(scorer); ok {
+		fmt.Printf("%s: %d, %s: %d\n", p1.Name, sc.Score(p1), p2.Name, sc.Score(p2))
+	}
 	if w := g.GetWinner(); w != nil {
 		fmt.Printf("Player %s wins!\n", w.Name)
 	} else {
 		fmt.Println("It is a draw. Nobody wins!")
 	}
+	if pb, ok := b.(*Simple2DBoard); ok {
+		if pos, err := FormatPosition(pb, ""); err == nil {
+			fmt.Printf("Position: %s\n", pos)
+		}
+	}
+	fmt.Println("\nMoves:")
+	for i, m := range g.History() {
+		fmt.Printf("%d. %v\n", i+1, m)
+	}
+
+	if *sgfOut != "" {
+		if err := saveSGF(*sgfOut, gm); err != nil {
+			fmt.Printf("Writing the SGF failed: %v\n", err)
+		} else {
+			fmt.Printf("Wrote the game to %s.\n", *sgfOut)
+		}
+	}
+	if *recordPath != "" {
+		if err := appendRecord(*recordPath, gm); err != nil {
+			fmt.Printf("Recording the game failed: %v\n", err)
+		} else {
+			fmt.Printf("Appended the game to %s.\n", *recordPath)
+		}
+	}
+
+	fmt.Println("\nAnalyzing the game...")
+	mistakes, err := ai.Analyze(g, func() (GameLogic, error) {
+		replayed, err := newLogic(gm.variant, p1, p2)
+		return replayed.logic, err
+	}, gm.analyzer())
+	switch {
+	case err != nil:
+		fmt.Printf("The analysis failed: %v\n", err)
+	case len(mistakes) == 0:
+		fmt.Println("Nobody lost a won or drawn game by a mistake.")
+	}
+	for _, m := range mistakes {
+		fmt.Printf("Mistake in ply %v\n", m)
+	}
 
 }
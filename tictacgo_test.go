@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/er4z0r/tictacgo/games"
+)
+
+func TestLoadGameSameSymbols(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tictacgo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := GameState{Game: variants[0], Players: []*Player{{Name: "Alice", Symbol: "o"}, {Name: "Bob", Symbol: "o"}}}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "same.json")
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadGame(path); err == nil {
+		t.Errorf("loadGame failed. Loaded a game of two players with the same symbol")
+	}
+}
+
+func TestReadCoords(t *testing.T) {
+	r := strings.NewReader("1 2\nh\n1\n1 x\n1 2 3\n\n3 4")
+	if coords, command, err := readCoords(r, 2); err != nil || command != "" || len(coords) != 2 || coords[0] != 1 || coords[1] != 2 {
+		t.Errorf("readCoords failed. Returned %v, %q (%v) instead of [1 2]", coords, command, err)
+	}
+	if _, command, err := readCoords(r, 2); err != nil || command != "h" {
+		t.Errorf("readCoords failed. Returned %q (%v) instead of the command h", command, err)
+	}
+	for i := 0; i < 4; i++ {
+		if coords, _, err := readCoords(r, 2); err == nil {
+			t.Errorf("readCoords failed. Accepted invalid input as %v", coords)
+		}
+	}
+	if coords, _, err := readCoords(r, 2); err != nil || coords[0] != 3 || coords[1] != 4 {
+		t.Errorf("readCoords failed. Returned %v (%v) after invalid input instead of [3 4]", coords, err)
+	}
+	if _, _, err := readCoords(r, 2); err != io.EOF {
+		t.Errorf("readCoords failed. Returned %v instead of io.EOF at the end of the input", err)
+	}
+}